@@ -1,19 +1,86 @@
 package argon2id
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/subtle"
-	"encoding/base64"
-	"errors"
 	"fmt"
-	"strings"
 
+	"github.com/imylam/crypto-utils/hmac"
+	"github.com/imylam/crypto-utils/signature"
 	"golang.org/x/crypto/argon2"
 )
 
 const ALGO string = "argon2id"
 
 func Sign(configs *Argon2Configs, password string) (signature string, err error) {
+	return hashPassword(configs, []byte(password), "", nil)
+}
+
+func Verify(signature, password string) (bool, error) {
+	return verifyPassword(signature, []byte(password), nil)
+}
+
+// SignContext is Sign unless ctx is already done, and stops waiting for
+// the hash once ctx is done while hashing completes in the background.
+func SignContext(ctx context.Context, configs *Argon2Configs, password string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	var hash string
+	var hashErr error
+
+	err := signature.Run(ctx, func() {
+		hash, hashErr = Sign(configs, password)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hash, hashErr
+}
+
+// VerifyContext is Verify unless ctx is already done, and stops waiting
+// for the result once ctx is done while hashing completes in the
+// background.
+func VerifyContext(ctx context.Context, hash, password string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	var match bool
+	var verifyErr error
+
+	err := signature.Run(ctx, func() {
+		match, verifyErr = Verify(hash, password)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return match, verifyErr
+}
+
+func hashPassword(
+	configs *Argon2Configs,
+	password []byte,
+	pepperID string,
+	peppers map[string][]byte,
+) (signature string, err error) {
+	if err := configs.Check(); err != nil {
+		return "", err
+	}
+
+	if err := hmac.CheckPepperID(pepperID); err != nil {
+		return "", err
+	}
+
+	password, err = hmac.Pepper(password, pepperID, peppers)
+	if err != nil {
+		return "", err
+	}
+
 	salt, err := genPasswordSalt(16)
 	if err != nil {
 		return "", err
@@ -21,7 +88,7 @@ func Sign(configs *Argon2Configs, password string) (signature string, err error)
 
 	// Execute Argon2id hashing algorithm
 	hashRaw := argon2.IDKey(
-		[]byte(password),
+		password,
 		salt,
 		configs.TimeCost,
 		configs.MemoryCost,
@@ -30,30 +97,24 @@ func Sign(configs *Argon2Configs, password string) (signature string, err error)
 	)
 
 	// Generate standardized hash format
-	signature = fmt.Sprintf(
-		"$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
-		ALGO,
-		argon2.Version,
-		configs.MemoryCost,
-		configs.TimeCost,
-		configs.Threads,
-		base64.RawStdEncoding.EncodeToString(salt),
-		base64.RawStdEncoding.EncodeToString(hashRaw),
-	)
-
-	return
+	return encodeHash(configs, pepperID, salt, hashRaw), nil
 }
 
-func Verify(signature, password string) (bool, error) {
+func verifyPassword(signature string, password []byte, peppers map[string][]byte) (bool, error) {
 	// Parse stored hash parameters
-	hash, salt, configs, err := parseHash(signature)
+	hash, salt, configs, pepperID, err := parseHash(signature)
 	if err != nil {
 		return false, fmt.Errorf("hash parsing failed: %w", err)
 	}
 
+	password, err = hmac.Pepper(password, pepperID, peppers)
+	if err != nil {
+		return false, err
+	}
+
 	// Generate hash using identical parameters
 	computedHash := argon2.IDKey(
-		[]byte(password),
+		password,
 		salt,
 		configs.TimeCost,
 		configs.MemoryCost,
@@ -67,41 +128,6 @@ func Verify(signature, password string) (bool, error) {
 	return match, nil
 }
 
-func parseHash(encodedHash string) (hash, salt []byte, configs Argon2Configs, err error) {
-	components := strings.Split(encodedHash, "$")
-	if len(components) != 6 {
-		return []byte{}, []byte{}, configs, errors.New("invalid hash format structure")
-	}
-
-	// Validate algorithm identifier
-	if !strings.HasPrefix(components[1], ALGO) {
-		return []byte{}, []byte{}, configs, errors.New("unsupported algorithm variant")
-	}
-
-	// Extract version information
-	var version int
-	fmt.Sscanf(components[2], "v=%d", &version)
-
-	// Parse configuration parameters
-	fmt.Sscanf(components[3], "m=%d,t=%d,p=%d", &configs.MemoryCost, &configs.TimeCost, &configs.Threads)
-
-	// Decode salt component
-	salt, err = base64.RawStdEncoding.DecodeString(components[4])
-	if err != nil {
-		return []byte{}, []byte{}, configs, errors.New("unsupported algorithm variant")
-	}
-
-	// Decode hash component
-	hash, err = base64.RawStdEncoding.DecodeString(components[5])
-	if err != nil {
-		return []byte{}, []byte{}, configs, errors.New("unsupported algorithm variant")
-	}
-
-	configs.KeyLength = uint32(len(hash))
-
-	return
-}
-
 func genPasswordSalt(saltSize uint32) ([]byte, error) {
 	salt := make([]byte, saltSize)
 	_, err := rand.Read(salt)
@@ -0,0 +1,107 @@
+package argon2id
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/imylam/crypto-utils/signature"
+	textcoder "github.com/imylam/text-coder"
+)
+
+const (
+	ERR_MISMATCHED_PASSWORD = "hashed password does not match the hash of password provided"
+)
+
+var _ signature.Signer = (*Argon2id)(nil)
+var _ signature.Verifier = (*Argon2id)(nil)
+var _ signature.PasswordHasher = (*Argon2id)(nil)
+var _ signature.ContextSigner = (*Argon2id)(nil)
+var _ signature.ContextVerifier = (*Argon2id)(nil)
+
+type Argon2id struct {
+	configs  *Argon2Configs
+	pwCoder  textcoder.Coder
+	pepperID string
+	peppers  map[string][]byte
+}
+
+// NewArgon2id creates password hasher which hash passwords with the
+// configs given into the standard "$argon2id$v=19$m=..,t=..,p=..$salt$hash"
+// format.
+//
+// Implements signature.Signer and signature.Verifier.
+func NewArgon2id(
+	configs *Argon2Configs,
+	pwCoder textcoder.Coder,
+	options ...func(*Argon2id),
+) *Argon2id {
+	a := &Argon2id{
+		configs: configs,
+		pwCoder: pwCoder,
+	}
+	for _, o := range options {
+		o(a)
+	}
+	return a
+}
+
+// Algo returns the algorithm used for signing/verifying.
+func (a *Argon2id) Algo() (algo string) {
+	return ALGO
+}
+
+// Sign implements signature.Signer.
+func (a *Argon2id) Sign(
+	pw string,
+) (pwHash string, err error) {
+	pwBytes, err := a.pwCoder.Decode(pw)
+	if err != nil {
+		err = fmt.Errorf("failed to decode password: %w", err)
+		return
+	}
+
+	return hashPassword(a.configs, pwBytes, a.pepperID, a.peppers)
+}
+
+// Verify implements signature.Verifier.
+func (a *Argon2id) Verify(pw string, hash string) (err error) {
+	pwBytes, err := a.pwCoder.Decode(pw)
+	if err != nil {
+		err = fmt.Errorf("failed to decode password: %w", err)
+		return
+	}
+
+	match, err := verifyPassword(hash, pwBytes, a.peppers)
+	if err != nil {
+		err = fmt.Errorf("failed to decode hash: %w", err)
+		return
+	}
+
+	if !match {
+		err = signature.ErrMismatchedPassword
+	}
+
+	return
+}
+
+// NeedsRehash reports whether hash was produced with configs or pepper
+// other than those of the hasher.
+func (a *Argon2id) NeedsRehash(hash string) (bool, error) {
+	_, _, configs, pepperID, err := parseHash(hash)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode hash: %w", err)
+	}
+
+	return configs != *a.configs || pepperID != a.pepperID, nil
+}
+
+// SignContext signs password unless ctx is done, see signature.SignContext.
+func (a *Argon2id) SignContext(ctx context.Context, pw string) (string, error) {
+	return signature.SignContext(ctx, a, pw)
+}
+
+// VerifyContext verifies password against hash unless ctx is done, see
+// signature.VerifyContext.
+func (a *Argon2id) VerifyContext(ctx context.Context, pw string, hash string) error {
+	return signature.VerifyContext(ctx, a, pw, hash)
+}
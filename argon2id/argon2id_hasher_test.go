@@ -0,0 +1,139 @@
+package argon2id
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/imylam/crypto-utils/signature"
+	textcoder "github.com/imylam/text-coder"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	argon2idPw = NewArgon2id(newConfigs(), &textcoder.Utf8Coder{})
+)
+
+func TestAlgo(t *testing.T) {
+	assert.Equal(t, ALGO, argon2idPw.Algo())
+}
+
+func TestVerifyOwnPasswordHash(t *testing.T) {
+	testPw := "lorem ipsum"
+
+	t.Run("GIVEN_same_password_WHEN_verifing_own_hash_THEN_no_error", func(t *testing.T) {
+		hash, err := argon2idPw.Sign(testPw)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=2,p=4$"))
+
+		err = argon2idPw.Verify(testPw, hash)
+		assert.NoError(t, err)
+	})
+
+	t.Run("GIVEN_different_password_WHEN_verifing_own_hash_THEN_return_error", func(t *testing.T) {
+		hash, err := argon2idPw.Sign(testPw)
+		assert.NoError(t, err)
+
+		err = argon2idPw.Verify("password", hash)
+
+		assert.ErrorContainsf(
+			t,
+			err,
+			ERR_MISMATCHED_PASSWORD,
+			"expected error containing %q, got %s", ERR_MISMATCHED_PASSWORD, err,
+		)
+	})
+
+	t.Run("GIVEN_hash_of_free_function_WHEN_verifying_THEN_no_error", func(t *testing.T) {
+		hash, err := Sign(newConfigs(), testPw)
+		assert.NoError(t, err)
+
+		err = argon2idPw.Verify(testPw, hash)
+		assert.NoError(t, err)
+	})
+}
+
+func TestWrongPasswordOrHashShouldThrowError(t *testing.T) {
+	utf8Pw := "abc"
+	errDecodePw := "failed to decode password:"
+	errDecodeHash := "failed to decode hash:"
+
+	hexArgon2id := NewArgon2id(newConfigs(), &textcoder.HexCoder{})
+
+	t.Run("GIVEN_wrong_password_coding_WHEN_signing_THEN_return_err", func(t *testing.T) {
+		hash, err := hexArgon2id.Sign(utf8Pw)
+
+		assert.Empty(t, hash)
+		assert.ErrorContainsf(
+			t,
+			err,
+			errDecodePw,
+			"expected error containing %q, got %s", errDecodePw, err,
+		)
+	})
+
+	t.Run("GIVEN_wrong_password_coding_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		hash, _ := argon2idPw.Sign(utf8Pw)
+
+		err := hexArgon2id.Verify(utf8Pw, hash)
+
+		assert.ErrorContainsf(
+			t,
+			err,
+			errDecodePw,
+			"expected error containing %q, got %s", errDecodePw, err,
+		)
+	})
+
+	t.Run("GIVEN_malformatted_hash_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		err := argon2idPw.Verify(utf8Pw, "hello")
+
+		assert.ErrorContainsf(
+			t,
+			err,
+			errDecodeHash,
+			"expected error containing %q, got %s", errDecodeHash, err,
+		)
+	})
+}
+
+func TestNeedsRehash(t *testing.T) {
+	t.Run("GIVEN_hash_of_same_configs_WHEN_checking_THEN_return_false", func(t *testing.T) {
+		hash, err := argon2idPw.Sign(PhcPassword)
+		assert.NoError(t, err)
+
+		needsRehash, err := argon2idPw.NeedsRehash(hash)
+
+		assert.NoError(t, err)
+		assert.False(t, needsRehash)
+	})
+
+	t.Run("GIVEN_hash_of_other_configs_WHEN_checking_THEN_return_true", func(t *testing.T) {
+		needsRehash, err := argon2idPw.NeedsRehash(PhcHash)
+
+		assert.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("GIVEN_malformatted_hash_WHEN_checking_THEN_return_err", func(t *testing.T) {
+		_, err := argon2idPw.NeedsRehash("hello")
+
+		assert.ErrorIs(t, err, ErrMalformedHash)
+	})
+}
+
+func TestVerifyAndUpgrade(t *testing.T) {
+	t.Run("GIVEN_outdated_hash_WHEN_verifying_THEN_return_new_hash", func(t *testing.T) {
+		newHash, err := signature.VerifyAndUpgrade(argon2idPw, PhcPassword, PhcHash)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(newHash, "$argon2id$v=19$m=65536,t=2,p=4$"))
+
+		assert.NoError(t, argon2idPw.Verify(PhcPassword, newHash))
+	})
+
+	t.Run("GIVEN_outdated_hash_and_wrong_password_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		newHash, err := signature.VerifyAndUpgrade(argon2idPw, "wrong password", PhcHash)
+
+		assert.Empty(t, newHash)
+		assert.ErrorContains(t, err, ERR_MISMATCHED_PASSWORD)
+	})
+}
@@ -1,6 +1,7 @@
 package argon2id
 
 import (
+	"context"
 	"testing"
 
 	stringsdk "github.com/imylam/crypto-utils/string-sdk"
@@ -55,3 +56,22 @@ func newConfigs() *Argon2Configs {
 		KeyLength:  32,
 	}
 }
+
+func TestSignContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := SignContext(ctx, newConfigs(), "password")
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestVerifyContextRoundTrip(t *testing.T) {
+	hash, err := SignContext(context.Background(), newConfigs(), "password")
+	assert.NoError(t, err)
+
+	isMatch, err := VerifyContext(context.Background(), hash, "password")
+
+	assert.NoError(t, err)
+	assert.True(t, isMatch)
+}
@@ -0,0 +1,73 @@
+package argon2id
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/imylam/crypto-utils/internal/calibrate"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	calibrationKeyLength = 32
+)
+
+var (
+	ErrCalibrationFailed = errors.New("no argon2id configs meet the target latency")
+)
+
+// measure is replaced in tests to simulate hardware.
+var measure = func(configs *Argon2Configs) time.Duration {
+	salt := make([]byte, 16)
+
+	return calibrate.Time(func() {
+		argon2.IDKey([]byte("password"), salt, configs.TimeCost, configs.MemoryCost, configs.Threads, configs.KeyLength)
+	})
+}
+
+// Calibrate benchmarks the current machine and returns the strongest
+// configs hashing within targetLatency, using at most maxMemoryCost KiB
+// of memory and the threads given. Memory is searched before passes, see
+// calibrate.Search.
+func Calibrate(
+	targetLatency time.Duration,
+	maxMemoryCost uint32,
+	threads uint8,
+) (*Argon2Configs, error) {
+	configs := &Argon2Configs{
+		TimeCost:   1,
+		MemoryCost: maxMemoryCost,
+		Threads:    threads,
+		KeyLength:  calibrationKeyLength,
+	}
+	if configs.MemoryCost > MaxMemoryCost {
+		configs.MemoryCost = MaxMemoryCost
+	}
+	if err := configs.Check(); err != nil {
+		return nil, err
+	}
+
+	memoryCost, timeCost, ok := calibrate.Search(
+		targetLatency,
+		int(configs.MemoryCost),
+		8*int(threads),
+		MaxTimeCost,
+		func(memoryCost, timeCost int) time.Duration {
+			return measure(&Argon2Configs{
+				TimeCost:   uint32(timeCost),
+				MemoryCost: uint32(memoryCost),
+				Threads:    threads,
+				KeyLength:  calibrationKeyLength,
+			})
+		},
+	)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCalibrationFailed, targetLatency)
+	}
+
+	configs.MemoryCost = uint32(memoryCost)
+	configs.TimeCost = uint32(timeCost)
+
+	return configs, nil
+}
@@ -0,0 +1,72 @@
+package argon2id
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// simulateHardware replaces measure by a machine hashing 1 MiB per pass
+// in perMiB.
+func simulateHardware(t *testing.T, perMiB time.Duration) {
+	original := measure
+	t.Cleanup(func() { measure = original })
+
+	measure = func(configs *Argon2Configs) time.Duration {
+		return time.Duration(configs.TimeCost) * time.Duration(configs.MemoryCost) * perMiB / 1024
+	}
+}
+
+func TestCalibrate(t *testing.T) {
+	t.Run("GIVEN_memory_budget_within_latency_WHEN_calibrating_THEN_use_all_memory_and_add_passes", func(t *testing.T) {
+		simulateHardware(t, time.Millisecond)
+
+		configs, err := Calibrate(500*time.Millisecond, 64*1024, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Argon2Configs{TimeCost: 7, MemoryCost: 64 * 1024, Threads: 4, KeyLength: 32}, configs)
+	})
+
+	t.Run("GIVEN_memory_budget_over_latency_WHEN_calibrating_THEN_halve_memory", func(t *testing.T) {
+		simulateHardware(t, time.Millisecond)
+
+		configs, err := Calibrate(100*time.Millisecond, 1024*1024, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Argon2Configs{TimeCost: 1, MemoryCost: 64 * 1024, Threads: 4, KeyLength: 32}, configs)
+	})
+
+	t.Run("GIVEN_clock_measuring_0_WHEN_calibrating_THEN_return_max_passes", func(t *testing.T) {
+		simulateHardware(t, 0)
+
+		configs, err := Calibrate(time.Second, 64*1024, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Argon2Configs{TimeCost: MaxTimeCost, MemoryCost: 64 * 1024, Threads: 4, KeyLength: 32}, configs)
+	})
+
+	t.Run("GIVEN_unreachable_latency_WHEN_calibrating_THEN_return_err", func(t *testing.T) {
+		simulateHardware(t, time.Second)
+
+		configs, err := Calibrate(time.Millisecond, 64*1024, 4)
+
+		assert.Nil(t, configs)
+		assert.ErrorIs(t, err, ErrCalibrationFailed)
+	})
+
+	t.Run("GIVEN_invalid_threads_WHEN_calibrating_THEN_return_err", func(t *testing.T) {
+		configs, err := Calibrate(time.Second, 64*1024, 0)
+
+		assert.Nil(t, configs)
+		assert.ErrorIs(t, err, ErrParameterOutOfRange)
+	})
+
+	t.Run("GIVEN_this_machine_WHEN_calibrating_THEN_return_valid_configs", func(t *testing.T) {
+		configs, err := Calibrate(200*time.Millisecond, 8*1024, 1)
+
+		assert.NoError(t, err)
+		assert.NoError(t, configs.Check())
+		assert.LessOrEqual(t, configs.MemoryCost, uint32(8*1024))
+	})
+}
@@ -1,5 +1,15 @@
 package argon2id
 
+import "fmt"
+
+const (
+	MaxTimeCost   = 64      // the maximum number of passes.
+	MaxMemoryCost = 1 << 20 // the maximum memory in KiB, i.e. 1 GiB.
+	MaxThreads    = 64      // the maximum degree of parallelism.
+	MinKeyLength  = 16      // the minimum hash length in bytes.
+	MaxKeyLength  = 1024    // the maximum hash length in bytes.
+)
+
 type Argon2Configs struct {
 	TimeCost   uint32
 	MemoryCost uint32
@@ -15,3 +25,26 @@ func DefaultConfigs() *Argon2Configs {
 		KeyLength:  32,
 	}
 }
+
+// Check returns ErrParameterOutOfRange if any config is outside of the
+// bounds accepted when hashing or verifying.
+func (c *Argon2Configs) Check() error {
+	if c.TimeCost < 1 || c.TimeCost > MaxTimeCost {
+		return fmt.Errorf("%w: t=%d", ErrParameterOutOfRange, c.TimeCost)
+	}
+
+	if c.Threads < 1 || c.Threads > MaxThreads {
+		return fmt.Errorf("%w: p=%d", ErrParameterOutOfRange, c.Threads)
+	}
+
+	// Argon2 requires at least 8 KiB of memory per lane.
+	if c.MemoryCost < 8*uint32(c.Threads) || c.MemoryCost > MaxMemoryCost {
+		return fmt.Errorf("%w: m=%d", ErrParameterOutOfRange, c.MemoryCost)
+	}
+
+	if c.KeyLength < MinKeyLength || c.KeyLength > MaxKeyLength {
+		return fmt.Errorf("%w: key length %d", ErrParameterOutOfRange, c.KeyLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package argon2id
+
+// WithPepper hashes passwords peppered by hmac.Pepper with the pepper of
+// keyID in keyring before argon2id, embedding keyID in the hash as
+// "pepper=<keyID>" parameter. Hashes without pepper are verified without
+// pepper.
+func WithPepper(
+	keyID string,
+	keyring map[string][]byte,
+) func(*Argon2id) {
+	return func(a *Argon2id) {
+		a.pepperID = keyID
+		a.peppers = keyring
+	}
+}
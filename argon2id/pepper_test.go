@@ -0,0 +1,93 @@
+package argon2id
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/imylam/crypto-utils/hmac"
+	textcoder "github.com/imylam/text-coder"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	pepperKeyring = map[string][]byte{
+		"2023": []byte("old pepper"),
+		"2024": []byte("new pepper"),
+	}
+	fastConfigs = &Argon2Configs{TimeCost: 1, MemoryCost: 8 * 1024, Threads: 1, KeyLength: 32}
+)
+
+func TestPepper(t *testing.T) {
+	oldPepper := NewArgon2id(fastConfigs, &textcoder.Utf8Coder{}, WithPepper("2023", pepperKeyring))
+	newPepper := NewArgon2id(fastConfigs, &textcoder.Utf8Coder{}, WithPepper("2024", pepperKeyring))
+	noPepper := NewArgon2id(fastConfigs, &textcoder.Utf8Coder{})
+
+	oldHash, err := oldPepper.Sign(PhcPassword)
+	assert.NoError(t, err)
+
+	t.Run("GIVEN_pepper_WHEN_signing_THEN_embed_pepper_key_id", func(t *testing.T) {
+		assert.True(t, strings.HasPrefix(oldHash, "$argon2id$v=19$m=8192,t=1,p=1,pepper=2023$"))
+	})
+
+	t.Run("GIVEN_hash_of_old_pepper_WHEN_verifying_with_keyring_THEN_no_error", func(t *testing.T) {
+		assert.NoError(t, newPepper.Verify(PhcPassword, oldHash))
+	})
+
+	t.Run("GIVEN_hash_of_old_pepper_WHEN_checking_THEN_return_true", func(t *testing.T) {
+		needsRehash, err := newPepper.NeedsRehash(oldHash)
+
+		assert.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("GIVEN_peppered_hash_WHEN_verifying_without_pepper_THEN_return_err", func(t *testing.T) {
+		err := noPepper.Verify(PhcPassword, oldHash)
+		assert.ErrorIs(t, err, hmac.ErrUnknownPepper)
+
+		_, err = Verify(oldHash, PhcPassword)
+		assert.ErrorIs(t, err, hmac.ErrUnknownPepper)
+	})
+
+	t.Run("GIVEN_peppered_hash_with_pepper_id_replaced_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		err := newPepper.Verify(PhcPassword, strings.Replace(oldHash, "pepper=2023", "pepper=2024", 1))
+
+		assert.ErrorContains(t, err, ERR_MISMATCHED_PASSWORD)
+	})
+
+	t.Run("GIVEN_peppered_hash_with_pepper_removed_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		err := newPepper.Verify(PhcPassword, strings.Replace(oldHash, ",pepper=2023", "", 1))
+
+		assert.ErrorContains(t, err, ERR_MISMATCHED_PASSWORD)
+	})
+
+	t.Run("GIVEN_hash_without_pepper_WHEN_verifying_with_pepper_THEN_no_error_and_need_rehash", func(t *testing.T) {
+		hash, err := noPepper.Sign(PhcPassword)
+		assert.NoError(t, err)
+
+		assert.NoError(t, newPepper.Verify(PhcPassword, hash))
+
+		needsRehash, err := newPepper.NeedsRehash(hash)
+		assert.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("GIVEN_pepper_key_id_not_in_keyring_WHEN_signing_THEN_return_err", func(t *testing.T) {
+		_, err := NewArgon2id(fastConfigs, &textcoder.Utf8Coder{}, WithPepper("2025", pepperKeyring)).Sign(PhcPassword)
+
+		assert.ErrorIs(t, err, hmac.ErrUnknownPepper)
+	})
+
+	t.Run("GIVEN_invalid_pepper_key_id_WHEN_signing_THEN_return_err", func(t *testing.T) {
+		keyring := map[string][]byte{"a$b": []byte("pepper")}
+
+		_, err := NewArgon2id(fastConfigs, &textcoder.Utf8Coder{}, WithPepper("a$b", keyring)).Sign(PhcPassword)
+
+		assert.ErrorIs(t, err, hmac.ErrInvalidPepperID)
+	})
+
+	t.Run("GIVEN_empty_pepper_key_id_in_hash_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		err := newPepper.Verify(PhcPassword, strings.Replace(oldHash, "pepper=2023", "pepper=", 1))
+
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+}
@@ -0,0 +1,146 @@
+package argon2id
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/imylam/crypto-utils/hmac"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	minSaltLength = 8  // the minimum salt length in bytes.
+	maxSaltLength = 64 // the maximum salt length in bytes.
+)
+
+var (
+	ErrMalformedHash       = errors.New("malformed argon2id hash")
+	ErrUnsupportedVariant  = errors.New("unsupported argon2 variant")
+	ErrUnsupportedVersion  = errors.New("unsupported argon2 version")
+	ErrInvalidParameters   = errors.New("invalid argon2id parameters")
+	ErrParameterOutOfRange = errors.New("argon2id parameter out of range")
+	ErrInvalidEncoding     = errors.New("invalid base64 encoding")
+)
+
+// phcEncoding is the unpadded standard base64 encoding required by the
+// PHC string format, rejecting non-canonical trailing bits.
+var phcEncoding = base64.RawStdEncoding.Strict()
+
+// encodeHash encodes hash in the PHC string format
+// "$argon2id$v=19$m=<memory>,t=<time>,p=<threads>[,pepper=<id>]$<salt>$<hash>".
+func encodeHash(configs *Argon2Configs, pepperID string, salt, hash []byte) string {
+	params := fmt.Sprintf("m=%d,t=%d,p=%d", configs.MemoryCost, configs.TimeCost, configs.Threads)
+	if pepperID != "" {
+		params += ",pepper=" + pepperID
+	}
+
+	return fmt.Sprintf(
+		"$%s$v=%d$%s$%s$%s",
+		ALGO,
+		argon2.Version,
+		params,
+		phcEncoding.EncodeToString(salt),
+		phcEncoding.EncodeToString(hash),
+	)
+}
+
+// parseHash decodes a hash in the PHC string format, validating every
+// field and bounding the parameters by Argon2Configs.Check.
+func parseHash(encodedHash string) (hash, salt []byte, configs Argon2Configs, pepperID string, err error) {
+	components := strings.Split(encodedHash, "$")
+	if len(components) != 6 || components[0] != "" {
+		return nil, nil, configs, "", ErrMalformedHash
+	}
+
+	// Validate algorithm identifier
+	if components[1] != ALGO {
+		return nil, nil, configs, "", fmt.Errorf("%w: %q", ErrUnsupportedVariant, components[1])
+	}
+
+	// Validate version
+	version, err := parseParam(components[2], "v", 32)
+	if err != nil {
+		return nil, nil, configs, "", fmt.Errorf("%w: %s", ErrMalformedHash, err)
+	}
+	if version != argon2.Version {
+		return nil, nil, configs, "", fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+
+	// Parse configuration parameters, in the order of the PHC format
+	params := strings.Split(components[3], ",")
+	if len(params) == 4 {
+		pepperID = strings.TrimPrefix(params[3], "pepper=")
+		if pepperID == params[3] || pepperID == "" || hmac.CheckPepperID(pepperID) != nil {
+			return nil, nil, configs, "", fmt.Errorf("%w: %q", ErrInvalidParameters, params[3])
+		}
+		params = params[:3]
+	}
+	if len(params) != 3 {
+		return nil, nil, configs, "", fmt.Errorf("%w: %q", ErrInvalidParameters, components[3])
+	}
+
+	memoryCost, err := parseParam(params[0], "m", 32)
+	if err != nil {
+		return nil, nil, configs, "", fmt.Errorf("%w: %s", ErrInvalidParameters, err)
+	}
+
+	timeCost, err := parseParam(params[1], "t", 32)
+	if err != nil {
+		return nil, nil, configs, "", fmt.Errorf("%w: %s", ErrInvalidParameters, err)
+	}
+
+	threads, err := parseParam(params[2], "p", 8)
+	if err != nil {
+		return nil, nil, configs, "", fmt.Errorf("%w: %s", ErrInvalidParameters, err)
+	}
+
+	// Decode salt component
+	salt, err = phcEncoding.DecodeString(components[4])
+	if err != nil {
+		return nil, nil, configs, "", fmt.Errorf("%w: salt: %s", ErrInvalidEncoding, err)
+	}
+	if len(salt) < minSaltLength || len(salt) > maxSaltLength {
+		return nil, nil, configs, "", fmt.Errorf("%w: salt length %d", ErrParameterOutOfRange, len(salt))
+	}
+
+	// Decode hash component
+	hash, err = phcEncoding.DecodeString(components[5])
+	if err != nil {
+		return nil, nil, configs, "", fmt.Errorf("%w: hash: %s", ErrInvalidEncoding, err)
+	}
+
+	configs = Argon2Configs{
+		TimeCost:   uint32(timeCost),
+		MemoryCost: uint32(memoryCost),
+		Threads:    uint8(threads),
+		KeyLength:  uint32(len(hash)),
+	}
+	if err := configs.Check(); err != nil {
+		return nil, nil, Argon2Configs{}, "", err
+	}
+
+	return hash, salt, configs, pepperID, nil
+}
+
+// parseParam parses a "<name>=<decimal>" parameter, rejecting signs and
+// leading zeros as required by the PHC string format.
+func parseParam(param, name string, bitSize int) (uint64, error) {
+	value := strings.TrimPrefix(param, name+"=")
+	if value == param || value == "" {
+		return 0, fmt.Errorf("expected %s=<decimal>, got %q", name, param)
+	}
+
+	if value[0] < '0' || value[0] > '9' || (len(value) > 1 && value[0] == '0') {
+		return 0, fmt.Errorf("invalid %s value %q", name, value)
+	}
+
+	n, err := strconv.ParseUint(value, 10, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value %q", name, value)
+	}
+
+	return n, nil
+}
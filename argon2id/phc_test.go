@@ -0,0 +1,166 @@
+package argon2id
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Hash of "password" salted with "somesalt" by the argon2 reference
+// implementation.
+const (
+	PhcPassword = "password"
+	PhcHash     = "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc"
+)
+
+func TestVerifyReferenceHash(t *testing.T) {
+	isMatch, err := Verify(PhcHash, PhcPassword)
+
+	assert.NoError(t, err)
+	assert.True(t, isMatch)
+}
+
+func TestEncodeHashRoundTrip(t *testing.T) {
+	hash, salt, configs, pepperID, err := parseHash(PhcHash)
+	assert.NoError(t, err)
+	assert.Equal(t, Argon2Configs{TimeCost: 2, MemoryCost: 65536, Threads: 1, KeyLength: 32}, configs)
+
+	assert.Empty(t, pepperID)
+
+	assert.Equal(t, PhcHash, encodeHash(&configs, pepperID, salt, hash))
+}
+
+func TestParseInvalidHashShouldThrowError(t *testing.T) {
+	salt := "c29tZXNhbHQ"
+	hash := "CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc"
+	phc := func(variant, version, params string) string {
+		return strings.Join([]string{"", variant, version, params, salt, hash}, "$")
+	}
+
+	testCases := []struct {
+		name        string
+		hash        string
+		expectedErr error
+	}{
+		{
+			name:        "GIVEN_missing_field_WHEN_parsing_THEN_return_err",
+			hash:        "$argon2id$v=19$m=65536,t=2,p=1$" + salt,
+			expectedErr: ErrMalformedHash,
+		},
+		{
+			name:        "GIVEN_no_leading_dollar_WHEN_parsing_THEN_return_err",
+			hash:        strings.TrimPrefix(PhcHash, "$"),
+			expectedErr: ErrMalformedHash,
+		},
+		{
+			name:        "GIVEN_argon2i_WHEN_parsing_THEN_return_err",
+			hash:        phc("argon2i", "v=19", "m=65536,t=2,p=1"),
+			expectedErr: ErrUnsupportedVariant,
+		},
+		{
+			name:        "GIVEN_variant_with_suffix_WHEN_parsing_THEN_return_err",
+			hash:        phc("argon2idx", "v=19", "m=65536,t=2,p=1"),
+			expectedErr: ErrUnsupportedVariant,
+		},
+		{
+			name:        "GIVEN_version_16_WHEN_parsing_THEN_return_err",
+			hash:        phc(ALGO, "v=16", "m=65536,t=2,p=1"),
+			expectedErr: ErrUnsupportedVersion,
+		},
+		{
+			name:        "GIVEN_non_numeric_version_WHEN_parsing_THEN_return_err",
+			hash:        phc(ALGO, "v=x", "m=65536,t=2,p=1"),
+			expectedErr: ErrMalformedHash,
+		},
+		{
+			name:        "GIVEN_params_out_of_order_WHEN_parsing_THEN_return_err",
+			hash:        phc(ALGO, "v=19", "t=2,m=65536,p=1"),
+			expectedErr: ErrInvalidParameters,
+		},
+		{
+			name:        "GIVEN_missing_param_WHEN_parsing_THEN_return_err",
+			hash:        phc(ALGO, "v=19", "m=65536,t=2"),
+			expectedErr: ErrInvalidParameters,
+		},
+		{
+			name:        "GIVEN_param_with_leading_zero_WHEN_parsing_THEN_return_err",
+			hash:        phc(ALGO, "v=19", "m=065536,t=2,p=1"),
+			expectedErr: ErrInvalidParameters,
+		},
+		{
+			name:        "GIVEN_param_with_sign_WHEN_parsing_THEN_return_err",
+			hash:        phc(ALGO, "v=19", "m=65536,t=+2,p=1"),
+			expectedErr: ErrInvalidParameters,
+		},
+		{
+			name:        "GIVEN_threads_overflowing_uint8_WHEN_parsing_THEN_return_err",
+			hash:        phc(ALGO, "v=19", "m=65536,t=2,p=257"),
+			expectedErr: ErrInvalidParameters,
+		},
+		{
+			name:        "GIVEN_memory_over_max_WHEN_parsing_THEN_return_err",
+			hash:        phc(ALGO, "v=19", "m=4194304,t=2,p=1"),
+			expectedErr: ErrParameterOutOfRange,
+		},
+		{
+			name:        "GIVEN_time_over_max_WHEN_parsing_THEN_return_err",
+			hash:        phc(ALGO, "v=19", "m=65536,t=1000,p=1"),
+			expectedErr: ErrParameterOutOfRange,
+		},
+		{
+			name:        "GIVEN_zero_threads_WHEN_parsing_THEN_return_err",
+			hash:        phc(ALGO, "v=19", "m=65536,t=2,p=0"),
+			expectedErr: ErrParameterOutOfRange,
+		},
+		{
+			name:        "GIVEN_memory_under_8_KiB_per_thread_WHEN_parsing_THEN_return_err",
+			hash:        phc(ALGO, "v=19", "m=16,t=2,p=4"),
+			expectedErr: ErrParameterOutOfRange,
+		},
+		{
+			name:        "GIVEN_padded_salt_WHEN_parsing_THEN_return_err",
+			hash:        strings.Replace(PhcHash, salt, salt+"=", 1),
+			expectedErr: ErrInvalidEncoding,
+		},
+		{
+			name:        "GIVEN_non_canonical_salt_WHEN_parsing_THEN_return_err",
+			hash:        strings.Replace(PhcHash, salt, "c29tZXNhbHR", 1),
+			expectedErr: ErrInvalidEncoding,
+		},
+		{
+			name:        "GIVEN_short_salt_WHEN_parsing_THEN_return_err",
+			hash:        strings.Replace(PhcHash, salt, "c2FsdA", 1),
+			expectedErr: ErrParameterOutOfRange,
+		},
+		{
+			name:        "GIVEN_invalid_hash_encoding_WHEN_parsing_THEN_return_err",
+			hash:        strings.Replace(PhcHash, hash, "!"+hash, 1),
+			expectedErr: ErrInvalidEncoding,
+		},
+		{
+			name:        "GIVEN_short_hash_WHEN_parsing_THEN_return_err",
+			hash:        strings.Replace(PhcHash, hash, "c2FsdA", 1),
+			expectedErr: ErrParameterOutOfRange,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			isMatch, err := Verify(tc.hash, PhcPassword)
+
+			assert.False(t, isMatch)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestSignWithInvalidConfigsShouldThrowError(t *testing.T) {
+	configs := newConfigs()
+	configs.MemoryCost = MaxMemoryCost + 1
+
+	hash, err := Sign(configs, PhcPassword)
+
+	assert.Empty(t, hash)
+	assert.ErrorIs(t, err, ErrParameterOutOfRange)
+}
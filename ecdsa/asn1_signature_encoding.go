@@ -0,0 +1,51 @@
+package ecdsa
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+)
+
+const (
+	ASN1 = "ASN1"
+)
+
+type asn1SignatureEncoding struct {
+	signatureEncoding string
+}
+
+// NewAsn1SignatureEncoding creates signature encoding which encodes
+// signature as an ASN.1 DER sequence of r and s.
+func NewAsn1SignatureEncoding() *asn1SignatureEncoding {
+	return &asn1SignatureEncoding{signatureEncoding: ASN1}
+}
+
+func (e *asn1SignatureEncoding) SignatureEncoding() string {
+	return e.signatureEncoding
+}
+
+func (e *asn1SignatureEncoding) Sign(
+	privateKey *ecdsa.PrivateKey,
+	hashedMsgBytes []byte,
+) (signatureBytes []byte, err error) {
+	rng := rand.Reader
+
+	signatureBytes, err = ecdsa.SignASN1(rng, privateKey, hashedMsgBytes)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (e *asn1SignatureEncoding) Verify(
+	publicKey *ecdsa.PublicKey,
+	hashedMsgBytes,
+	signatureBytes []byte,
+) error {
+	if !ecdsa.VerifyASN1(publicKey, hashedMsgBytes, signatureBytes) {
+		return errors.New(ERR_INVALID_SIGNATURE)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package ecdsa
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"fmt"
+)
+
+const (
+	ERR_INVALID_SIGNATURE = "invalid signature"
+)
+
+// Sign a message with PrivateKey using ECDSA and the crypto hash given
+func Sign(
+	hash crypto.Hash,
+	curve elliptic.Curve,
+	signatureEncoding SignatureEncoding,
+	privateKey *ecdsa.PrivateKey,
+	messageBytes []byte,
+) (signature []byte, err error) {
+	if err = checkCurve(curve, privateKey.Curve); err != nil {
+		return
+	}
+
+	hasher := hash.New()
+	hasher.Write(messageBytes)
+	hashedMessage := hasher.Sum(nil)
+
+	return signatureEncoding.Sign(privateKey, hashedMessage)
+}
+
+// Verify a signature with PublicKey using ECDSA and the crypto hash given
+func Verify(
+	hash crypto.Hash,
+	curve elliptic.Curve,
+	signatureEncoding SignatureEncoding,
+	publicKey *ecdsa.PublicKey,
+	messageBytes, signatureBytes []byte,
+) (err error) {
+	if err = checkCurve(curve, publicKey.Curve); err != nil {
+		return
+	}
+
+	hasher := hash.New()
+	hasher.Write(messageBytes)
+	hashedMessageBytes := hasher.Sum(nil)
+
+	return signatureEncoding.Verify(publicKey, hashedMessageBytes, signatureBytes)
+}
+
+func checkCurve(expected, actual elliptic.Curve) error {
+	if actual == nil || actual.Params().Name != expected.Params().Name {
+		return fmt.Errorf("key is not on curve %s", expected.Params().Name)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package ecdsa
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+const (
+	JOSE = "JOSE"
+)
+
+type joseSignatureEncoding struct {
+	signatureEncoding string
+}
+
+// NewJoseSignatureEncoding creates signature encoding which encodes
+// signature as the fixed-length concatenation r||s used by JWS.
+func NewJoseSignatureEncoding() *joseSignatureEncoding {
+	return &joseSignatureEncoding{signatureEncoding: JOSE}
+}
+
+func (e *joseSignatureEncoding) SignatureEncoding() string {
+	return e.signatureEncoding
+}
+
+func (e *joseSignatureEncoding) Sign(
+	privateKey *ecdsa.PrivateKey,
+	hashedMsgBytes []byte,
+) (signatureBytes []byte, err error) {
+	rng := rand.Reader
+
+	r, s, err := ecdsa.Sign(rng, privateKey, hashedMsgBytes)
+	if err != nil {
+		return
+	}
+
+	size := curveByteSize(privateKey.Curve.Params().BitSize)
+	signatureBytes = make([]byte, 2*size)
+	r.FillBytes(signatureBytes[:size])
+	s.FillBytes(signatureBytes[size:])
+
+	return
+}
+
+func (e *joseSignatureEncoding) Verify(
+	publicKey *ecdsa.PublicKey,
+	hashedMsgBytes,
+	signatureBytes []byte,
+) error {
+	size := curveByteSize(publicKey.Curve.Params().BitSize)
+	if len(signatureBytes) != 2*size {
+		return errors.New(ERR_INVALID_SIGNATURE)
+	}
+
+	r := new(big.Int).SetBytes(signatureBytes[:size])
+	s := new(big.Int).SetBytes(signatureBytes[size:])
+
+	if !ecdsa.Verify(publicKey, hashedMsgBytes, r, s) {
+		return errors.New(ERR_INVALID_SIGNATURE)
+	}
+
+	return nil
+}
+
+func curveByteSize(bitSize int) int {
+	return (bitSize + 7) / 8
+}
@@ -0,0 +1,11 @@
+package ecdsa
+
+import (
+	"crypto/ecdsa"
+)
+
+type SignatureEncoding interface {
+	SignatureEncoding() string
+	Sign(*ecdsa.PrivateKey, []byte) ([]byte, error)
+	Verify(*ecdsa.PublicKey, []byte, []byte) error
+}
@@ -1,6 +1,6 @@
 module github.com/imylam/crypto-utils
 
-go 1.18
+go 1.20
 
 require (
 	github.com/imylam/text-coder v0.0.0-20231006071032-43f1d3a209bb
@@ -0,0 +1,52 @@
+package hmac
+
+import (
+	"crypto"
+	_ "crypto/sha256" // registers crypto.SHA256
+	"errors"
+	"fmt"
+)
+
+const (
+	MaxPepperIDLength = 32
+)
+
+var (
+	ErrUnknownPepper   = errors.New("unknown pepper key ID")
+	ErrInvalidPepperID = errors.New("invalid pepper key ID")
+)
+
+// Pepper returns password keyed by HMAC-SHA256 with the pepper of keyID in
+// keyring, or password as is if keyID is empty.
+//
+// Password hashers embed keyID in their hashes and select the pepper from
+// keyring by it when verifying, so peppers can be rotated by adding a new
+// key ID and keeping the old ones.
+func Pepper(password []byte, keyID string, keyring map[string][]byte) ([]byte, error) {
+	if keyID == "" {
+		return password, nil
+	}
+
+	pepper, ok := keyring[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPepper, keyID)
+	}
+
+	return Sign(crypto.SHA256, pepper, password), nil
+}
+
+// CheckPepperID returns ErrInvalidPepperID unless keyID is at most
+// MaxPepperIDLength characters allowed in PHC parameter values.
+func CheckPepperID(keyID string) error {
+	if len(keyID) > MaxPepperIDLength {
+		return fmt.Errorf("%w: %q", ErrInvalidPepperID, keyID)
+	}
+
+	for _, c := range keyID {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '/' || c == '+' || c == '.' || c == '-') {
+			return fmt.Errorf("%w: %q", ErrInvalidPepperID, keyID)
+		}
+	}
+
+	return nil
+}
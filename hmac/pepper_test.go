@@ -0,0 +1,58 @@
+package hmac
+
+import (
+	"crypto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPepper(t *testing.T) {
+	keyring := map[string][]byte{"1": []byte("pepper")}
+
+	t.Run("GIVEN_key_id_in_keyring_WHEN_peppering_THEN_return_hmac_of_password", func(t *testing.T) {
+		peppered, err := Pepper([]byte("password"), "1", keyring)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Sign(crypto.SHA256, []byte("pepper"), []byte("password")), peppered)
+	})
+
+	t.Run("GIVEN_empty_key_id_WHEN_peppering_THEN_return_password", func(t *testing.T) {
+		peppered, err := Pepper([]byte("password"), "", keyring)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("password"), peppered)
+	})
+
+	t.Run("GIVEN_key_id_not_in_keyring_WHEN_peppering_THEN_return_err", func(t *testing.T) {
+		_, err := Pepper([]byte("password"), "2", keyring)
+
+		assert.ErrorIs(t, err, ErrUnknownPepper)
+	})
+}
+
+func TestCheckPepperID(t *testing.T) {
+	testCases := []struct {
+		name  string
+		keyID string
+		valid bool
+	}{
+		{"GIVEN_phc_characters_WHEN_checking_THEN_no_error", "2024.a-B/c+9", true},
+		{"GIVEN_comma_WHEN_checking_THEN_return_err", "a,b", false},
+		{"GIVEN_dollar_WHEN_checking_THEN_return_err", "a$b", false},
+		{"GIVEN_key_id_over_max_length_WHEN_checking_THEN_return_err", strings.Repeat("a", MaxPepperIDLength+1), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckPepperID(tc.keyID)
+
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidPepperID)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Package calibrate searches the cost of memory-hard hashes meeting a
+// target latency on the current machine.
+package calibrate
+
+import "time"
+
+// Search returns the strongest memory and multiplier whose hash, timed by
+// measure, meets targetLatency.
+//
+// Memory is preferred over the multiplier as per RFC 9106: memory is
+// halved from maxMemory, but not below minMemory, until a hash with
+// multiplier 1 meets targetLatency, then the multiplier is raised up to
+// maxMultiplier while latency allows, assuming latency grows linearly
+// with it. ok is false if no memory meets targetLatency.
+func Search(
+	targetLatency time.Duration,
+	maxMemory, minMemory, maxMultiplier int,
+	measure func(memory, multiplier int) time.Duration,
+) (memory, multiplier int, ok bool) {
+	memory = maxMemory
+	latency := measure(memory, 1)
+	for latency > targetLatency {
+		memory /= 2
+		if memory < minMemory {
+			return 0, 0, false
+		}
+		latency = measure(memory, 1)
+	}
+
+	// A clock coarser than a hash measures 0.
+	if latency <= 0 {
+		latency = 1
+	}
+
+	multiplier = maxMultiplier
+	if n := targetLatency / latency; n < time.Duration(maxMultiplier) {
+		multiplier = int(n)
+	}
+
+	for ; multiplier > 1; multiplier-- {
+		if measure(memory, multiplier) <= targetLatency {
+			break
+		}
+	}
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	return memory, multiplier, true
+}
+
+// Time returns the time taken by hash.
+func Time(hash func()) time.Duration {
+	start := time.Now()
+	hash()
+	return time.Since(start)
+}
@@ -0,0 +1,82 @@
+package calibrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// linear is a machine hashing memory 1 with multiplier 1 in unit.
+func linear(unit time.Duration) func(memory, multiplier int) time.Duration {
+	return func(memory, multiplier int) time.Duration {
+		return time.Duration(memory*multiplier) * unit
+	}
+}
+
+func TestSearch(t *testing.T) {
+	testCases := []struct {
+		name       string
+		target     time.Duration
+		measure    func(memory, multiplier int) time.Duration
+		memory     int
+		multiplier int
+		ok         bool
+	}{
+		{
+			name:       "GIVEN_max_memory_within_latency_WHEN_searching_THEN_raise_multiplier",
+			target:     100 * time.Millisecond,
+			measure:    linear(time.Millisecond),
+			memory:     32,
+			multiplier: 3,
+			ok:         true,
+		},
+		{
+			name:       "GIVEN_max_memory_over_latency_WHEN_searching_THEN_halve_memory",
+			target:     20 * time.Millisecond,
+			measure:    linear(time.Millisecond),
+			memory:     16,
+			multiplier: 1,
+			ok:         true,
+		},
+		{
+			name:       "GIVEN_fast_machine_WHEN_searching_THEN_cap_multiplier",
+			target:     time.Second,
+			measure:    linear(time.Nanosecond),
+			memory:     32,
+			multiplier: 10,
+			ok:         true,
+		},
+		{
+			name:       "GIVEN_clock_measuring_0_WHEN_searching_THEN_cap_multiplier",
+			target:     time.Second,
+			measure:    linear(0),
+			memory:     32,
+			multiplier: 10,
+			ok:         true,
+		},
+		{
+			name:       "GIVEN_clock_measuring_0_and_target_0_WHEN_searching_THEN_return_multiplier_1",
+			target:     0,
+			measure:    linear(0),
+			memory:     32,
+			multiplier: 1,
+			ok:         true,
+		},
+		{
+			name:    "GIVEN_unreachable_latency_WHEN_searching_THEN_not_ok",
+			target:  time.Millisecond,
+			measure: linear(time.Second),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			memory, multiplier, ok := Search(tc.target, 32, 4, 10, tc.measure)
+
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.memory, memory)
+			assert.Equal(t, tc.multiplier, multiplier)
+		})
+	}
+}
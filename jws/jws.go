@@ -0,0 +1,91 @@
+package jws
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	ALGO_NONE = "none"
+
+	HEADER_ALGORITHM = "alg"
+	HEADER_KEY_ID    = "kid"
+	HEADER_TYPE      = "typ"
+	HEADER_CRITICAL  = "crit"
+
+	ERR_MALFORMATTED_TOKEN   = "malformatted token"
+	ERR_MISSING_ALGORITHM    = "missing alg header"
+	ERR_UNSECURED_ALGORITHM  = "unsecured alg none is not allowed"
+	ERR_UNSUPPORTED_CRITICAL = "unsupported crit header"
+	ERR_NO_VERIFIER          = "no verifier found for alg and kid"
+)
+
+type TokenSigner interface {
+	Algo() string
+	Sign([]byte) (string, error)
+}
+
+type TokenVerifier interface {
+	Verify(string) (Header, []byte, error)
+}
+
+// Header is the JOSE header of a JWS.
+type Header map[string]interface{}
+
+// Algorithm returns the alg header parameter.
+func (h Header) Algorithm() string {
+	return h.stringParam(HEADER_ALGORITHM)
+}
+
+// KeyID returns the kid header parameter.
+func (h Header) KeyID() string {
+	return h.stringParam(HEADER_KEY_ID)
+}
+
+// Type returns the typ header parameter.
+func (h Header) Type() string {
+	return h.stringParam(HEADER_TYPE)
+}
+
+func (h Header) stringParam(name string) string {
+	v, _ := h[name].(string)
+	return v
+}
+
+// Parse splits a JWS in compact serialization into its decoded header,
+// payload and signature without verifying the signature.
+//
+// signingInput is the "header.payload" part the signature is computed over.
+func Parse(token string) (header Header, payload []byte, signingInput, signature string, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		err = errors.New(ERR_MALFORMATTED_TOKEN)
+		return
+	}
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		err = fmt.Errorf("failed to decode header: %w", err)
+		return
+	}
+
+	err = json.Unmarshal(headerBytes, &header)
+	if err != nil {
+		err = fmt.Errorf("failed to decode header: %w", err)
+		return
+	}
+
+	payload, err = base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		err = fmt.Errorf("failed to decode payload: %w", err)
+		return
+	}
+
+	signingInput = parts[0] + "." + parts[1]
+	signature = parts[2]
+
+	return
+}
@@ -0,0 +1,90 @@
+package jws
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/imylam/crypto-utils/signature"
+)
+
+var _ TokenSigner = (*signer)(nil)
+
+type signer struct {
+	algoSigner signature.Signer
+	header     Header
+}
+
+// NewSigner creates signer which produce JWS in compact serialization,
+// taking the alg header from algoSigner.Algo().
+//
+// algoSigner must decode messages with textcoder.Utf8Coder and encode
+// signatures with textcoder.Base64RawUrlCoder.
+func NewSigner(
+	algoSigner signature.Signer,
+	options ...func(*signer),
+) *signer {
+	s := &signer{
+		algoSigner: algoSigner,
+		header:     Header{},
+	}
+	for _, o := range options {
+		o(s)
+	}
+	return s
+}
+
+// WithKeyID sets the kid header.
+func WithKeyID(kid string) func(*signer) {
+	return func(s *signer) {
+		s.header[HEADER_KEY_ID] = kid
+	}
+}
+
+// WithType sets the typ header.
+func WithType(typ string) func(*signer) {
+	return func(s *signer) {
+		s.header[HEADER_TYPE] = typ
+	}
+}
+
+// WithHeader sets a custom header. The alg header cannot be overridden.
+func WithHeader(name string, value interface{}) func(*signer) {
+	return func(s *signer) {
+		s.header[name] = value
+	}
+}
+
+// Algo returns the algorithm used for signing.
+func (s *signer) Algo() string {
+	return s.algoSigner.Algo()
+}
+
+// Sign payload and return JWS in compact serialization.
+func (s *signer) Sign(payload []byte) (token string, err error) {
+	header := Header{}
+	for k, v := range s.header {
+		header[k] = v
+	}
+	header[HEADER_ALGORITHM] = s.algoSigner.Algo()
+
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		err = fmt.Errorf("failed to encode header: %w", err)
+		return
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerBytes) +
+		"." +
+		base64.RawURLEncoding.EncodeToString(payload)
+
+	sig, err := s.algoSigner.Sign(signingInput)
+	if err != nil {
+		err = fmt.Errorf("failed to sign token: %w", err)
+		return
+	}
+
+	token = signingInput + "." + sig
+
+	return
+}
@@ -0,0 +1,104 @@
+package jws
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/imylam/crypto-utils/signature"
+)
+
+type keyedVerifier struct {
+	kid      string
+	verifier signature.Verifier
+}
+
+var _ TokenVerifier = (*verifier)(nil)
+
+type verifier struct {
+	verifiers []keyedVerifier
+}
+
+// NewVerifier creates verifier which verify JWS in compact serialization,
+// selecting among the verifiers given by the alg and kid headers.
+//
+// Verifiers must decode messages with textcoder.Utf8Coder and decode
+// signatures with textcoder.Base64RawUrlCoder.
+func NewVerifier(
+	options ...func(*verifier),
+) *verifier {
+	v := &verifier{}
+	for _, o := range options {
+		o(v)
+	}
+	return v
+}
+
+// WithVerifier adds a verifier used for tokens whose alg matches
+// algoVerifier.Algo(), regardless of kid.
+func WithVerifier(algoVerifier signature.Verifier) func(*verifier) {
+	return WithKeyVerifier("", algoVerifier)
+}
+
+// WithKeyVerifier adds a verifier used for tokens whose alg matches
+// algoVerifier.Algo() and whose kid is the kid given.
+func WithKeyVerifier(kid string, algoVerifier signature.Verifier) func(*verifier) {
+	return func(v *verifier) {
+		v.verifiers = append(v.verifiers, keyedVerifier{kid: kid, verifier: algoVerifier})
+	}
+}
+
+// Verify token and return its header and payload.
+func (v *verifier) Verify(token string) (header Header, payload []byte, err error) {
+	header, payload, signingInput, sig, err := Parse(token)
+	if err != nil {
+		err = fmt.Errorf("failed to parse token: %w", err)
+		return nil, nil, err
+	}
+
+	algo := header.Algorithm()
+	if algo == "" {
+		return nil, nil, errors.New(ERR_MISSING_ALGORITHM)
+	}
+	if algo == ALGO_NONE {
+		return nil, nil, errors.New(ERR_UNSECURED_ALGORITHM)
+	}
+	if _, ok := header[HEADER_CRITICAL]; ok {
+		return nil, nil, errors.New(ERR_UNSUPPORTED_CRITICAL)
+	}
+
+	candidates := v.candidates(algo, header.KeyID())
+	if len(candidates) == 0 {
+		return nil, nil, fmt.Errorf("%s: alg=%q kid=%q", ERR_NO_VERIFIER, algo, header.KeyID())
+	}
+
+	for _, candidate := range candidates {
+		err = candidate.Verify(signingInput, sig)
+		if err == nil {
+			return header, payload, nil
+		}
+	}
+
+	return nil, nil, err
+}
+
+// candidates returns verifiers for algo registered with kid, falling back
+// to those registered without a kid.
+func (v *verifier) candidates(algo, kid string) []signature.Verifier {
+	var keyed, unkeyed []signature.Verifier
+	for _, kv := range v.verifiers {
+		if kv.verifier.Algo() != algo {
+			continue
+		}
+		switch {
+		case kv.kid == "":
+			unkeyed = append(unkeyed, kv.verifier)
+		case kv.kid == kid:
+			keyed = append(keyed, kv.verifier)
+		}
+	}
+
+	if len(keyed) > 0 {
+		return keyed
+	}
+	return unkeyed
+}
@@ -0,0 +1,178 @@
+package jwt
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	CLAIM_ISSUER     = "iss"
+	CLAIM_SUBJECT    = "sub"
+	CLAIM_AUDIENCE   = "aud"
+	CLAIM_EXPIRES_AT = "exp"
+	CLAIM_NOT_BEFORE = "nbf"
+	CLAIM_ISSUED_AT  = "iat"
+	CLAIM_ID         = "jti"
+)
+
+// Claims is a JWT claims set. Registered claims have their own fields,
+// any other claim is kept in Custom.
+type Claims struct {
+	Issuer    string
+	Subject   string
+	Audience  Audience
+	ExpiresAt *NumericDate
+	NotBefore *NumericDate
+	IssuedAt  *NumericDate
+	ID        string
+	Custom    map[string]interface{}
+}
+
+// MarshalJSON encodes registered and custom claims into a single
+// JSON object. Registered claims take precedence over custom ones.
+func (c Claims) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(c.Custom)+7)
+	for k, v := range c.Custom {
+		m[k] = v
+	}
+
+	if c.Issuer != "" {
+		m[CLAIM_ISSUER] = c.Issuer
+	}
+	if c.Subject != "" {
+		m[CLAIM_SUBJECT] = c.Subject
+	}
+	if len(c.Audience) > 0 {
+		m[CLAIM_AUDIENCE] = c.Audience
+	}
+	if c.ExpiresAt != nil {
+		m[CLAIM_EXPIRES_AT] = c.ExpiresAt
+	}
+	if c.NotBefore != nil {
+		m[CLAIM_NOT_BEFORE] = c.NotBefore
+	}
+	if c.IssuedAt != nil {
+		m[CLAIM_ISSUED_AT] = c.IssuedAt
+	}
+	if c.ID != "" {
+		m[CLAIM_ID] = c.ID
+	}
+
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes a JSON object into registered and custom claims.
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*c = Claims{}
+
+	registered := map[string]interface{}{
+		CLAIM_ISSUER:     &c.Issuer,
+		CLAIM_SUBJECT:    &c.Subject,
+		CLAIM_AUDIENCE:   &c.Audience,
+		CLAIM_EXPIRES_AT: &c.ExpiresAt,
+		CLAIM_NOT_BEFORE: &c.NotBefore,
+		CLAIM_ISSUED_AT:  &c.IssuedAt,
+		CLAIM_ID:         &c.ID,
+	}
+
+	for name, value := range raw {
+		target, ok := registered[name]
+		if !ok {
+			if c.Custom == nil {
+				c.Custom = map[string]interface{}{}
+			}
+			target = new(interface{})
+			if err := json.Unmarshal(value, target); err != nil {
+				return fmt.Errorf("invalid %s claim: %w", name, err)
+			}
+			c.Custom[name] = *(target.(*interface{}))
+			continue
+		}
+
+		if err := json.Unmarshal(value, target); err != nil {
+			return fmt.Errorf("invalid %s claim: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Audience is the aud claim, which may be a single string or an array.
+type Audience []string
+
+// Contains reports whether aud is one of the audiences.
+func (a Audience) Contains(aud string) bool {
+	for _, v := range a {
+		if v == aud {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON encodes a single audience as a string and more as an array.
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+// UnmarshalJSON decodes an audience from a string or an array of strings.
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+
+	return nil
+}
+
+// NumericDate is a JSON numeric date, seconds since the Unix epoch.
+type NumericDate struct {
+	time.Time
+}
+
+// NewNumericDate creates NumericDate from t truncated to seconds.
+func NewNumericDate(t time.Time) *NumericDate {
+	return &NumericDate{t.Truncate(time.Second)}
+}
+
+// MarshalJSON encodes the date as seconds since the Unix epoch.
+func (d NumericDate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(d.Unix(), 10)), nil
+}
+
+// UnmarshalJSON decodes the date from seconds since the Unix epoch,
+// which may have a fractional part.
+func (d *NumericDate) UnmarshalJSON(data []byte) error {
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+
+	f, err := number.Float64()
+	if err != nil {
+		return err
+	}
+
+	sec, frac := math.Modf(f)
+	d.Time = time.Unix(int64(sec), int64(frac*1e9))
+
+	return nil
+}
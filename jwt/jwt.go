@@ -0,0 +1,18 @@
+package jwt
+
+import (
+	"errors"
+)
+
+const (
+	TYPE = "JWT"
+)
+
+var (
+	ErrTokenExpired          = errors.New("token is expired")
+	ErrTokenNotYetValid      = errors.New("token is not valid yet")
+	ErrTokenUsedBeforeIssued = errors.New("token used before issued")
+	ErrMissingExpiration     = errors.New("token has no exp claim")
+	ErrInvalidIssuer         = errors.New("token has invalid iss claim")
+	ErrInvalidAudience       = errors.New("token has invalid aud claim")
+)
@@ -0,0 +1,38 @@
+package jwt
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/imylam/crypto-utils/jws"
+)
+
+type signer struct {
+	tokenSigner jws.TokenSigner
+}
+
+// NewSigner creates signer which sign claims set as JWS with tokenSigner.
+//
+// To set the typ header to "JWT", create tokenSigner with
+// jws.WithType(jwt.TYPE).
+func NewSigner(tokenSigner jws.TokenSigner) *signer {
+	return &signer{
+		tokenSigner: tokenSigner,
+	}
+}
+
+// Algo returns the algorithm used for signing.
+func (s *signer) Algo() string {
+	return s.tokenSigner.Algo()
+}
+
+// Sign claims and return JWT.
+func (s *signer) Sign(claims Claims) (token string, err error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		err = fmt.Errorf("failed to encode claims: %w", err)
+		return
+	}
+
+	return s.tokenSigner.Sign(payload)
+}
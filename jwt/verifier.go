@@ -0,0 +1,123 @@
+package jwt
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/imylam/crypto-utils/jws"
+)
+
+type verifier struct {
+	tokenVerifier      jws.TokenVerifier
+	clock              func() time.Time
+	clockSkew          time.Duration
+	issuer             string
+	audience           string
+	expirationOptional bool
+}
+
+// NewVerifier creates verifier which verify JWT with tokenVerifier
+// and validate its registered claims.
+//
+// By default, the exp claim is required and no clock skew is allowed.
+func NewVerifier(
+	tokenVerifier jws.TokenVerifier,
+	options ...func(*verifier),
+) *verifier {
+	v := &verifier{
+		tokenVerifier: tokenVerifier,
+		clock:         time.Now,
+	}
+	for _, o := range options {
+		o(v)
+	}
+	return v
+}
+
+// WithClock sets the clock used to validate exp, nbf and iat.
+func WithClock(clock func() time.Time) func(*verifier) {
+	return func(v *verifier) {
+		v.clock = clock
+	}
+}
+
+// WithClockSkew sets the leeway allowed when validating exp, nbf and iat.
+func WithClockSkew(clockSkew time.Duration) func(*verifier) {
+	return func(v *verifier) {
+		v.clockSkew = clockSkew
+	}
+}
+
+// WithIssuer requires the iss claim to be issuer.
+func WithIssuer(issuer string) func(*verifier) {
+	return func(v *verifier) {
+		v.issuer = issuer
+	}
+}
+
+// WithAudience requires the aud claim to contain audience.
+func WithAudience(audience string) func(*verifier) {
+	return func(v *verifier) {
+		v.audience = audience
+	}
+}
+
+// WithOptionalExpiration accepts tokens without an exp claim.
+func WithOptionalExpiration() func(*verifier) {
+	return func(v *verifier) {
+		v.expirationOptional = true
+	}
+}
+
+// Verify token and return its claims.
+func (v *verifier) Verify(token string) (claims Claims, err error) {
+	_, payload, err := v.tokenVerifier.Verify(token)
+	if err != nil {
+		err = fmt.Errorf("failed to verify token: %w", err)
+		return
+	}
+
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		err = fmt.Errorf("failed to decode claims: %w", err)
+		return
+	}
+
+	err = v.validate(claims)
+	if err != nil {
+		return Claims{}, fmt.Errorf("failed to validate claims: %w", err)
+	}
+
+	return
+}
+
+func (v *verifier) validate(claims Claims) error {
+	now := v.clock()
+
+	if claims.ExpiresAt == nil {
+		if !v.expirationOptional {
+			return ErrMissingExpiration
+		}
+	} else if !now.Before(claims.ExpiresAt.Add(v.clockSkew)) {
+		return ErrTokenExpired
+	}
+
+	if claims.NotBefore != nil && now.Add(v.clockSkew).Before(claims.NotBefore.Time) {
+		return ErrTokenNotYetValid
+	}
+
+	if claims.IssuedAt != nil && now.Add(v.clockSkew).Before(claims.IssuedAt.Time) {
+		return ErrTokenUsedBeforeIssued
+	}
+
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return ErrInvalidIssuer
+	}
+
+	if v.audience != "" && !claims.Audience.Contains(v.audience) {
+		return ErrInvalidAudience
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package password
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/imylam/crypto-utils/argon2id"
+	"github.com/imylam/crypto-utils/signature"
+	"github.com/imylam/crypto-utils/signature/bcrypt"
+	"github.com/imylam/crypto-utils/signature/pbkdf2"
+	"github.com/imylam/crypto-utils/signature/scrypt"
+	textcoder "github.com/imylam/text-coder"
+)
+
+const (
+	ALGO_ARGON2ID = argon2id.ALGO
+	ALGO_SCRYPT   = scrypt.ALGO
+	ALGO_BCRYPT   = bcrypt.ALGO
+	ALGO_PBKDF2   = "pbkdf2"
+)
+
+var (
+	ErrUnknownAlgorithm = errors.New("unknown password hash algorithm")
+)
+
+var _ signature.PasswordHasher = (*Hasher)(nil)
+var _ signature.ContextSigner = (*Hasher)(nil)
+var _ signature.ContextVerifier = (*Hasher)(nil)
+
+// Hasher hashes passwords with a preferred algorithm and verifies hashes
+// of any supported algorithm, detected from the hash prefix:
+//   - "$argon2id$" for argon2id,
+//   - "$scrypt$", or the "N$r$p$salt$dk" shape, for scrypt,
+//   - "$2a$", "$2b$" or "$2y$" for bcrypt,
+//   - "pbkdf2_" or "pbkdf2:" for PBKDF2 in the Django or werkzeug format.
+type Hasher struct {
+	preferred     string
+	pwCoder       textcoder.Coder
+	argon2Configs *argon2id.Argon2Configs
+	scryptParams  scrypt.Params
+	scryptCoder   textcoder.Coder
+	bcryptCost    int
+	pbkdf2Params  pbkdf2.Params
+	pepperID      string
+	peppers       map[string][]byte
+}
+
+// NewHasher creates password hasher which hash passwords with the
+// preferred algorithm, one of ALGO_*.
+//
+// By default, argon2id hashes with argon2id.DefaultConfigs, scrypt with
+// scrypt.DefaultParams in the PHC string format, bcrypt with
+// bcrypt.DefaultCost and PBKDF2 with pbkdf2.DefaultParams.
+//
+// Implements signature.PasswordHasher.
+func NewHasher(
+	preferred string,
+	pwCoder textcoder.Coder,
+	options ...func(*Hasher),
+) *Hasher {
+	h := &Hasher{
+		preferred:     preferred,
+		pwCoder:       pwCoder,
+		argon2Configs: argon2id.DefaultConfigs(),
+		scryptParams:  scrypt.DefaultParams,
+		scryptCoder:   &textcoder.HexCoder{},
+		bcryptCost:    bcrypt.DefaultCost,
+		pbkdf2Params:  pbkdf2.DefaultParams,
+	}
+	for _, o := range options {
+		o(h)
+	}
+	return h
+}
+
+// WithArgon2Configs hashes argon2id passwords with the configs given.
+func WithArgon2Configs(configs *argon2id.Argon2Configs) func(*Hasher) {
+	return func(h *Hasher) {
+		h.argon2Configs = configs
+	}
+}
+
+// WithScryptParams hashes scrypt passwords with the params given.
+func WithScryptParams(params scrypt.Params) func(*Hasher) {
+	return func(h *Hasher) {
+		h.scryptParams = params
+	}
+}
+
+// WithScryptSigCoder decodes salt and derived key of scrypt hashes not in
+// the PHC string format with the coder given, hex by default.
+func WithScryptSigCoder(sigCoder textcoder.Coder) func(*Hasher) {
+	return func(h *Hasher) {
+		h.scryptCoder = sigCoder
+	}
+}
+
+// WithBcryptCost hashes bcrypt passwords with the cost given.
+func WithBcryptCost(cost int) func(*Hasher) {
+	return func(h *Hasher) {
+		h.bcryptCost = cost
+	}
+}
+
+// WithPbkdf2Params hashes PBKDF2 passwords with the params given.
+func WithPbkdf2Params(params pbkdf2.Params) func(*Hasher) {
+	return func(h *Hasher) {
+		h.pbkdf2Params = params
+	}
+}
+
+// WithPepper peppers argon2id and scrypt passwords with the pepper of
+// keyID in keyring, see argon2id.WithPepper and scrypt.WithPepper. Hashes
+// of key IDs missing from keyring fail with hmac.ErrUnknownPepper.
+func WithPepper(keyID string, keyring map[string][]byte) func(*Hasher) {
+	return func(h *Hasher) {
+		h.pepperID = keyID
+		h.peppers = keyring
+	}
+}
+
+// Algo returns the preferred algorithm used for signing.
+func (h *Hasher) Algo() string {
+	return h.preferred
+}
+
+// Sign implements signature.Signer, hashing pw with the preferred
+// algorithm.
+func (h *Hasher) Sign(pw string) (string, error) {
+	hasher, err := h.hasher(h.preferred)
+	if err != nil {
+		return "", err
+	}
+
+	return hasher.Sign(pw)
+}
+
+// Verify implements signature.Verifier, verifying pw with the algorithm
+// of hash.
+func (h *Hasher) Verify(pw string, hash string) error {
+	hasher, err := h.hasher(Algo(hash))
+	if err != nil {
+		return err
+	}
+
+	return hasher.Verify(pw, hash)
+}
+
+// NeedsRehash reports whether hash was produced with an algorithm other
+// than the preferred one, or with outdated parameters.
+func (h *Hasher) NeedsRehash(hash string) (bool, error) {
+	algo := Algo(hash)
+
+	hasher, err := h.hasher(algo)
+	if err != nil {
+		return false, err
+	}
+
+	needsRehash, err := hasher.NeedsRehash(hash)
+	if err != nil {
+		return false, err
+	}
+
+	return needsRehash || algo != h.preferred, nil
+}
+
+// Algo returns the algorithm of hash, one of ALGO_*, or an empty string
+// if it is unknown.
+func Algo(hash string) string {
+	switch {
+	case strings.HasPrefix(hash, "$"+argon2id.ALGO+"$"):
+		return ALGO_ARGON2ID
+	case strings.HasPrefix(hash, "$"+scrypt.ALGO+"$"):
+		return ALGO_SCRYPT
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return ALGO_BCRYPT
+	case strings.HasPrefix(hash, "pbkdf2_"), strings.HasPrefix(hash, "pbkdf2:"):
+		return ALGO_PBKDF2
+	case isLegacyScryptHash(hash):
+		return ALGO_SCRYPT
+	default:
+		return ""
+	}
+}
+
+// isLegacyScryptHash reports whether hash has the "N$r$p$salt$dk" shape of
+// scrypt hashes not in the PHC string format, with N, r and p in decimal.
+func isLegacyScryptHash(hash string) bool {
+	vals := strings.Split(hash, "$")
+	if len(vals) != 5 || vals[3] == "" || vals[4] == "" {
+		return false
+	}
+
+	for _, val := range vals[:3] {
+		if val == "" || strings.Trim(val, "0123456789") != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (h *Hasher) hasher(algo string) (signature.PasswordHasher, error) {
+	switch algo {
+	case ALGO_ARGON2ID:
+		if h.peppers != nil {
+			return argon2id.NewArgon2id(h.argon2Configs, h.pwCoder, argon2id.WithPepper(h.pepperID, h.peppers)), nil
+		}
+		return argon2id.NewArgon2id(h.argon2Configs, h.pwCoder), nil
+	case ALGO_SCRYPT:
+		if h.peppers != nil {
+			return scrypt.NewScrypt(h.scryptParams, h.pwCoder, h.scryptCoder, scrypt.WithPepper(h.pepperID, h.peppers)), nil
+		}
+		return scrypt.NewScrypt(h.scryptParams, h.pwCoder, h.scryptCoder, scrypt.WithPhcFormat()), nil
+	case ALGO_BCRYPT:
+		return bcrypt.NewBcrypt(h.bcryptCost, h.pwCoder), nil
+	case ALGO_PBKDF2:
+		return pbkdf2.NewPbkdf2(h.pbkdf2Params, h.pwCoder), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, algo)
+	}
+}
+
+// SignContext signs password unless ctx is done, see signature.SignContext.
+func (h *Hasher) SignContext(ctx context.Context, pw string) (string, error) {
+	return signature.SignContext(ctx, h, pw)
+}
+
+// VerifyContext verifies password against hash unless ctx is done, see
+// signature.VerifyContext.
+func (h *Hasher) VerifyContext(ctx context.Context, pw string, hash string) error {
+	return signature.VerifyContext(ctx, h, pw, hash)
+}
@@ -0,0 +1,169 @@
+package password
+
+import (
+	"crypto"
+	"strings"
+	"testing"
+
+	"github.com/imylam/crypto-utils/argon2id"
+	"github.com/imylam/crypto-utils/hmac"
+	"github.com/imylam/crypto-utils/signature"
+	"github.com/imylam/crypto-utils/signature/bcrypt"
+	"github.com/imylam/crypto-utils/signature/pbkdf2"
+	"github.com/imylam/crypto-utils/signature/scrypt"
+	textcoder "github.com/imylam/text-coder"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	Password = "password"
+)
+
+var (
+	argon2Configs = &argon2id.Argon2Configs{TimeCost: 1, MemoryCost: 8 * 1024, Threads: 1, KeyLength: 32}
+	scryptParams  = scrypt.Params{N: 1024, R: 8, P: 1, SaltLen: 16, DKLen: 32}
+	pbkdf2Params  = pbkdf2.Params{Hash: crypto.SHA256, Iterations: 1000, SaltLen: 16}
+)
+
+func newHasher(preferred string) *Hasher {
+	return NewHasher(
+		preferred,
+		&textcoder.Utf8Coder{},
+		WithArgon2Configs(argon2Configs),
+		WithScryptParams(scryptParams),
+		WithBcryptCost(bcrypt.MinCost),
+		WithPbkdf2Params(pbkdf2Params),
+	)
+}
+
+func TestAlgo(t *testing.T) {
+	testCases := []struct {
+		hash string
+		algo string
+	}{
+		{hash: "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", algo: ALGO_ARGON2ID},
+		{hash: "$scrypt$ln=14,r=8,p=1$c29tZXNhbHRzb21lc2FsdA$Yen7FjbO3BI6wXqNuTrPUFeKgwwVOT/w2/LyUQUT3Do", algo: ALGO_SCRYPT},
+		{hash: "32768$8$1$e6ade915861f38af$d8f83302984581f11ce4900473814d01d99a963af56038d96bf5260c05fef83d", algo: ALGO_SCRYPT},
+		{hash: "$2a$04$", algo: ALGO_BCRYPT},
+		{hash: "$2b$04$", algo: ALGO_BCRYPT},
+		{hash: "$2y$04$", algo: ALGO_BCRYPT},
+		{hash: "pbkdf2_sha256$600000$somesaltsomesalt$ivf+fieW0XSkO4ug266G8XCK0Fb1q2B2QH0uwQrFHLw=", algo: ALGO_PBKDF2},
+		{hash: "pbkdf2:sha512:1000$somesaltsomesalt$6b9c", algo: ALGO_PBKDF2},
+		{hash: "$argon2i$v=19$", algo: ""},
+		{hash: "$1$salt$hash", algo: ""},
+		{hash: "5f4dcc3b5aa765d61d8327deb882cf99", algo: ""},
+		{hash: "32768$8$1$e6ade915861f38af", algo: ""},
+		{hash: "32768$8$p$e6ade915861f38af$d8f8", algo: ""},
+		{hash: "32768$8$1$$d8f8", algo: ""},
+		{hash: "", algo: ""},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.algo, Algo(tc.hash), "hash %q", tc.hash)
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	for _, algo := range []string{ALGO_ARGON2ID, ALGO_SCRYPT, ALGO_BCRYPT, ALGO_PBKDF2} {
+		t.Run("GIVEN_preferred_"+algo+"_WHEN_signing_and_verifying_THEN_no_error", func(t *testing.T) {
+			hasher := newHasher(algo)
+
+			hash, err := hasher.Sign(Password)
+			assert.NoError(t, err)
+			assert.Equal(t, algo, Algo(hash))
+
+			assert.NoError(t, hasher.Verify(Password, hash))
+			assert.ErrorIs(t, hasher.Verify("wrong password", hash), signature.ErrMismatchedPassword)
+
+			needsRehash, err := hasher.NeedsRehash(hash)
+			assert.NoError(t, err)
+			assert.False(t, needsRehash)
+		})
+	}
+}
+
+func TestMigrateBetweenAlgorithms(t *testing.T) {
+	argon2Hasher := newHasher(ALGO_ARGON2ID)
+
+	for _, algo := range []string{ALGO_SCRYPT, ALGO_BCRYPT, ALGO_PBKDF2} {
+		t.Run("GIVEN_"+algo+"_hash_WHEN_verifying_with_preferred_argon2id_THEN_upgrade_to_argon2id", func(t *testing.T) {
+			hash, err := newHasher(algo).Sign(Password)
+			assert.NoError(t, err)
+
+			needsRehash, err := argon2Hasher.NeedsRehash(hash)
+			assert.NoError(t, err)
+			assert.True(t, needsRehash)
+
+			newHash, err := signature.VerifyAndUpgrade(argon2Hasher, Password, hash)
+			assert.NoError(t, err)
+			assert.Equal(t, ALGO_ARGON2ID, Algo(newHash))
+		})
+	}
+
+	t.Run("GIVEN_legacy_scrypt_hash_WHEN_verifying_THEN_no_error", func(t *testing.T) {
+		legacyScrypt := scrypt.NewScrypt(scryptParams, &textcoder.Utf8Coder{}, &textcoder.HexCoder{})
+		hash, err := legacyScrypt.Sign(Password)
+		assert.NoError(t, err)
+
+		assert.NoError(t, argon2Hasher.Verify(Password, hash))
+	})
+
+	t.Run("GIVEN_bcrypt_hash_of_other_cost_WHEN_checking_THEN_return_true", func(t *testing.T) {
+		hash, err := bcrypt.NewBcrypt(bcrypt.MinCost+1, &textcoder.Utf8Coder{}).Sign(Password)
+		assert.NoError(t, err)
+
+		needsRehash, err := newHasher(ALGO_BCRYPT).NeedsRehash(hash)
+		assert.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+}
+
+func TestUnknownAlgorithmShouldThrowError(t *testing.T) {
+	hasher := newHasher(ALGO_ARGON2ID)
+	md5Crypt := "$1$salt$hash"
+
+	t.Run("GIVEN_unknown_hash_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		assert.ErrorIs(t, hasher.Verify(Password, md5Crypt), ErrUnknownAlgorithm)
+	})
+
+	t.Run("GIVEN_unknown_hash_WHEN_checking_THEN_return_err", func(t *testing.T) {
+		_, err := hasher.NeedsRehash(md5Crypt)
+		assert.ErrorIs(t, err, ErrUnknownAlgorithm)
+	})
+
+	t.Run("GIVEN_unknown_preferred_algorithm_WHEN_signing_THEN_return_err", func(t *testing.T) {
+		hash, err := newHasher("md5").Sign(Password)
+
+		assert.Empty(t, hash)
+		assert.ErrorIs(t, err, ErrUnknownAlgorithm)
+	})
+
+	t.Run("GIVEN_bcrypt_password_over_72_bytes_WHEN_signing_THEN_return_err", func(t *testing.T) {
+		_, err := newHasher(ALGO_BCRYPT).Sign(strings.Repeat("a", 73))
+
+		assert.ErrorIs(t, err, bcrypt.ErrPasswordTooLong)
+	})
+}
+
+func TestPepper(t *testing.T) {
+	keyring := map[string][]byte{"1": []byte("pepper")}
+
+	for _, algo := range []string{ALGO_ARGON2ID, ALGO_SCRYPT} {
+		t.Run("GIVEN_pepper_and_preferred_"+algo+"_WHEN_signing_THEN_embed_pepper_key_id", func(t *testing.T) {
+			hasher := NewHasher(
+				algo,
+				&textcoder.Utf8Coder{},
+				WithArgon2Configs(argon2Configs),
+				WithScryptParams(scryptParams),
+				WithPepper("1", keyring),
+			)
+
+			hash, err := hasher.Sign(Password)
+			assert.NoError(t, err)
+			assert.Contains(t, hash, ",pepper=1$")
+
+			assert.NoError(t, hasher.Verify(Password, hash))
+			assert.ErrorIs(t, newHasher(algo).Verify(Password, hash), hmac.ErrUnknownPepper)
+		})
+	}
+}
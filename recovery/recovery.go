@@ -0,0 +1,168 @@
+package recovery
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/imylam/crypto-utils/signature"
+	stringsdk "github.com/imylam/crypto-utils/string-sdk"
+)
+
+const (
+	// ALPHABET leaves out 0, 1, I and O, which are easily confused when
+	// read or typed by hand.
+	ALPHABET = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+	DefaultCount     = 10
+	DefaultGroups    = 2
+	DefaultGroupLen  = 5
+	DefaultSeparator = "-"
+)
+
+var (
+	ErrInvalidCode    = errors.New("invalid recovery code")
+	ErrInvalidOptions = errors.New("invalid recovery code options")
+)
+
+// Codes generates one-time recovery codes and verifies them against their
+// hashes.
+type Codes struct {
+	hasher    signature.PasswordHasher
+	count     int
+	groups    int
+	groupLen  int
+	separator string
+}
+
+// NewCodes creates recovery codes generator which generates DefaultCount
+// codes of DefaultGroups groups of DefaultGroupLen characters from
+// ALPHABET, e.g. "7KQ3M-XW9PD" carrying 50 bits of entropy, and hashes
+// them with hasher, e.g. argon2id.NewArgon2id or scrypt.NewScrypt with a
+// UTF-8 password coder.
+func NewCodes(
+	hasher signature.PasswordHasher,
+	options ...func(*Codes),
+) *Codes {
+	c := &Codes{
+		hasher:    hasher,
+		count:     DefaultCount,
+		groups:    DefaultGroups,
+		groupLen:  DefaultGroupLen,
+		separator: DefaultSeparator,
+	}
+	for _, o := range options {
+		o(c)
+	}
+	return c
+}
+
+// WithCount generates count codes at a time.
+func WithCount(count int) func(*Codes) {
+	return func(c *Codes) {
+		c.count = count
+	}
+}
+
+// WithGroups generates codes of groups groups of groupLen characters.
+func WithGroups(groups, groupLen int) func(*Codes) {
+	return func(c *Codes) {
+		c.groups = groups
+		c.groupLen = groupLen
+	}
+}
+
+// WithSeparator joins groups of a code with separator.
+func WithSeparator(separator string) func(*Codes) {
+	return func(c *Codes) {
+		c.separator = separator
+	}
+}
+
+// Generate returns new codes to show to the user once, and their hashes
+// to store in their place.
+func (c *Codes) Generate() (codes []string, hashes []string, err error) {
+	if c.count <= 0 || c.groups <= 0 || c.groupLen <= 0 {
+		return nil, nil, ErrInvalidOptions
+	}
+
+	codes = make([]string, c.count)
+	hashes = make([]string, c.count)
+	for i := range codes {
+		code, err := stringsdk.SecureRandomStr(c.groups*c.groupLen, ALPHABET)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		hashes[i], err = c.hasher.Sign(code)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to hash recovery code: %w", err)
+		}
+
+		codes[i] = c.format(code)
+	}
+
+	return codes, hashes, nil
+}
+
+// Consume verifies code against hashes and returns hashes without the one
+// matched, to store in their place so that code cannot be used again.
+// Case, spaces and separators of code are ignored.
+//
+// Returns ErrInvalidCode if code matches none of hashes, or the error of
+// the hasher if it fails to verify code for another reason than
+// signature.ErrMismatchedPassword, e.g. a malformed hash. As each hash is
+// verified in turn, a miss costs len(hashes) verifications.
+func (c *Codes) Consume(code string, hashes []string) (remaining []string, err error) {
+	code, err = c.normalize(code)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, hash := range hashes {
+		err = c.hasher.Verify(code, hash)
+		if errors.Is(err, signature.ErrMismatchedPassword) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify recovery code: %w", err)
+		}
+
+		remaining = make([]string, 0, len(hashes)-1)
+		remaining = append(remaining, hashes[:i]...)
+		remaining = append(remaining, hashes[i+1:]...)
+		return remaining, nil
+	}
+
+	return nil, ErrInvalidCode
+}
+
+func (c *Codes) format(code string) string {
+	groups := make([]string, c.groups)
+	for i := range groups {
+		groups[i] = code[i*c.groupLen : (i+1)*c.groupLen]
+	}
+
+	return strings.Join(groups, c.separator)
+}
+
+// normalize strips code to upper case characters of ALPHABET, rejecting
+// codes which cannot be valid without hashing them.
+func (c *Codes) normalize(code string) (string, error) {
+	if c.separator != "" {
+		code = strings.ReplaceAll(code, c.separator, "")
+	}
+	code = strings.ToUpper(strings.Join(strings.Fields(code), ""))
+
+	if len(code) != c.groups*c.groupLen {
+		return "", ErrInvalidCode
+	}
+
+	for _, r := range code {
+		if !strings.ContainsRune(ALPHABET, r) {
+			return "", ErrInvalidCode
+		}
+	}
+
+	return code, nil
+}
@@ -0,0 +1,123 @@
+package recovery
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/imylam/crypto-utils/argon2id"
+	"github.com/imylam/crypto-utils/signature"
+	"github.com/imylam/crypto-utils/signature/scrypt"
+	textcoder "github.com/imylam/text-coder"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	argon2Configs = &argon2id.Argon2Configs{TimeCost: 1, MemoryCost: 8 * 1024, Threads: 1, KeyLength: 32}
+	scryptParams  = scrypt.Params{N: 1024, R: 8, P: 1, SaltLen: 16, DKLen: 32}
+)
+
+func newHashers() map[string]signature.PasswordHasher {
+	return map[string]signature.PasswordHasher{
+		"argon2id": argon2id.NewArgon2id(argon2Configs, &textcoder.Utf8Coder{}),
+		"scrypt":   scrypt.NewScrypt(scryptParams, &textcoder.Utf8Coder{}, &textcoder.HexCoder{}, scrypt.WithPhcFormat()),
+	}
+}
+
+func TestCodes(t *testing.T) {
+	for name, hasher := range newHashers() {
+		t.Run(name, func(t *testing.T) {
+			t.Run("GIVEN_defaults_WHEN_generating_THEN_return_grouped_codes_and_hashes", func(t *testing.T) {
+				codes, hashes, err := NewCodes(hasher).Generate()
+
+				assert.NoError(t, err)
+				assert.Len(t, codes, DefaultCount)
+				assert.Len(t, hashes, DefaultCount)
+				for i, code := range codes {
+					assert.Regexp(t, regexp.MustCompile("^["+ALPHABET+"]{5}-["+ALPHABET+"]{5}$"), code)
+					assert.NoError(t, hasher.Verify(strings.ReplaceAll(code, "-", ""), hashes[i]))
+				}
+			})
+
+			t.Run("GIVEN_valid_code_WHEN_consuming_THEN_remove_its_hash", func(t *testing.T) {
+				c := NewCodes(hasher, WithCount(3))
+				codes, hashes, err := c.Generate()
+				assert.NoError(t, err)
+
+				remaining, err := c.Consume(codes[1], hashes)
+
+				assert.NoError(t, err)
+				assert.Equal(t, []string{hashes[0], hashes[2]}, remaining)
+
+				_, err = c.Consume(codes[1], remaining)
+				assert.ErrorIs(t, err, ErrInvalidCode)
+			})
+
+			t.Run("GIVEN_malformed_hash_WHEN_consuming_THEN_return_hasher_err", func(t *testing.T) {
+				c := NewCodes(hasher, WithCount(1))
+				codes, _, err := c.Generate()
+				assert.NoError(t, err)
+
+				_, err = c.Consume(codes[0], []string{"malformed"})
+
+				assert.NotErrorIs(t, err, ErrInvalidCode)
+				assert.ErrorContains(t, err, "failed to verify recovery code: failed to decode hash")
+			})
+
+			t.Run("GIVEN_code_typed_loosely_WHEN_consuming_THEN_match", func(t *testing.T) {
+				c := NewCodes(hasher, WithCount(1))
+				codes, hashes, err := c.Generate()
+				assert.NoError(t, err)
+
+				remaining, err := c.Consume(" "+strings.ToLower(strings.ReplaceAll(codes[0], "-", " - ")), hashes)
+
+				assert.NoError(t, err)
+				assert.Empty(t, remaining)
+			})
+		})
+	}
+}
+
+func TestCodesOptions(t *testing.T) {
+	hasher := argon2id.NewArgon2id(argon2Configs, &textcoder.Utf8Coder{})
+
+	t.Run("GIVEN_groups_and_separator_WHEN_generating_THEN_format_codes", func(t *testing.T) {
+		codes, hashes, err := NewCodes(hasher, WithCount(2), WithGroups(3, 4), WithSeparator(" ")).Generate()
+
+		assert.NoError(t, err)
+		assert.Len(t, hashes, 2)
+		for _, code := range codes {
+			assert.Regexp(t, regexp.MustCompile("^["+ALPHABET+"]{4} ["+ALPHABET+"]{4} ["+ALPHABET+"]{4}$"), code)
+		}
+	})
+
+	t.Run("GIVEN_invalid_options_WHEN_generating_THEN_return_err", func(t *testing.T) {
+		_, _, err := NewCodes(hasher, WithGroups(0, 5)).Generate()
+
+		assert.ErrorIs(t, err, ErrInvalidOptions)
+	})
+}
+
+func TestConsumeRejectsMalformedCode(t *testing.T) {
+	hasher := argon2id.NewArgon2id(argon2Configs, &textcoder.Utf8Coder{})
+	c := NewCodes(hasher, WithCount(1))
+	_, hashes, err := c.Generate()
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		code string
+	}{
+		{"GIVEN_short_code_WHEN_consuming_THEN_return_err", "ABCDE-FGH"},
+		{"GIVEN_code_outside_alphabet_WHEN_consuming_THEN_return_err", "ABCDE-FGHI0"},
+		{"GIVEN_empty_code_WHEN_consuming_THEN_return_err", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := c.Consume(tc.code, hashes)
+
+			assert.ErrorIs(t, err, ErrInvalidCode)
+		})
+	}
+}
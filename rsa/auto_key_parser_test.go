@@ -0,0 +1,287 @@
+package rsa
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	autoPriKeyParser = &AutoPrivateKeyParser{Passphrase: []byte("password")}
+	autoPubKeyParser = &AutoPublicKeyParser{}
+)
+
+func TestSuccessAutoPrivateKeyParsing(t *testing.T) {
+	privateKey, _ := pkcs1PriKeyParser.Parse(pkcs1PriKeyPem)
+	priKeyJwk, _ := jwkPriKeyParser.Marshal(privateKey)
+	pkcs8PriKey, _ := pkcs8PriKeyParser.Parse(pkcs8PriKeyPem)
+	pkcs8Der, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+
+	testCases := []struct {
+		name        string
+		priKeyPem   string
+		expectedKey *rsa.PrivateKey
+	}{
+		{
+			name:        "GIVEN_autoPriKeyParser_WHEN_parse_pkcs1PrivateKeyPem_THEN_return_rsa_private_key",
+			priKeyPem:   pkcs1PriKeyPem,
+			expectedKey: privateKey,
+		},
+		{
+			name:        "GIVEN_autoPriKeyParser_WHEN_parse_pkcs8PrivateKeyPem_THEN_return_rsa_private_key",
+			priKeyPem:   pkcs8PriKeyPem,
+			expectedKey: pkcs8PriKey,
+		},
+		{
+			name:        "GIVEN_autoPriKeyParser_WHEN_parse_pkcs8_with_private_key_block_type_THEN_return_rsa_private_key",
+			priKeyPem:   string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Der})),
+			expectedKey: privateKey,
+		},
+		{
+			name:        "GIVEN_autoPriKeyParser_WHEN_parse_encrypted_pkcs8_THEN_return_rsa_private_key",
+			priKeyPem:   pbkdf2EncryptedPriKeyPem,
+			expectedKey: privateKey,
+		},
+		{
+			name:        "GIVEN_autoPriKeyParser_WHEN_parse_jwk_THEN_return_rsa_private_key",
+			priKeyPem:   priKeyJwk,
+			expectedKey: privateKey,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := autoPriKeyParser.Parse(tc.priKeyPem)
+
+			assert.NoError(t, err)
+			assert.True(t, tc.expectedKey.Equal(key))
+		})
+	}
+}
+
+func TestSuccessAutoPublicKeyParsing(t *testing.T) {
+	privateKey, _ := pkcs1PriKeyParser.Parse(pkcs1PriKeyPem)
+	pkixPubKey, _ := pkixPubKeyParser.Parse(pkixPubKeyPem)
+	pubKeyJwk, _ := jwkPubKeyParser.Marshal(&privateKey.PublicKey)
+
+	testCases := []struct {
+		name        string
+		pubKeyPem   string
+		expectedKey *rsa.PublicKey
+	}{
+		{
+			name:        "GIVEN_autoPubKeyParser_WHEN_parse_pkcs1PublicKeyPem_THEN_return_rsa_public_key",
+			pubKeyPem:   pkcs1PubKeyPem,
+			expectedKey: &privateKey.PublicKey,
+		},
+		{
+			name:        "GIVEN_autoPubKeyParser_WHEN_parse_pkixPublicKeyPem_THEN_return_rsa_public_key",
+			pubKeyPem:   pkixPubKeyPem,
+			expectedKey: pkixPubKey,
+		},
+		{
+			name:        "GIVEN_autoPubKeyParser_WHEN_parse_certificate_THEN_return_rsa_public_key",
+			pubKeyPem:   newCertificatePem(t, &privateKey.PublicKey, privateKey),
+			expectedKey: &privateKey.PublicKey,
+		},
+		{
+			name:        "GIVEN_autoPubKeyParser_WHEN_parse_jwk_THEN_return_rsa_public_key",
+			pubKeyPem:   pubKeyJwk,
+			expectedKey: &privateKey.PublicKey,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := autoPubKeyParser.Parse(tc.pubKeyPem)
+
+			assert.NoError(t, err)
+			assert.True(t, tc.expectedKey.Equal(key))
+		})
+	}
+}
+
+func TestFailedAutoKeyParsing(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ecPkcs8Der, _ := x509.MarshalPKCS8PrivateKey(ecKey)
+	ecPkixDer, _ := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+
+	ecPkcs8Pem := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecPkcs8Der}))
+	ecPkixPem := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: ecPkixDer}))
+	ecCertPem := newCertificatePem(t, &ecKey.PublicKey, ecKey)
+
+	rsaKey, err := (&Pkcs1PrivateKeyParser{}).Parse(pkcs1PriKeyPem)
+	assert.NoError(t, err)
+	rsaPkixDer, _ := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	rsaPkixInPrivateKeyBlockPem := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: rsaPkixDer}))
+	rsaPkcs1InPublicKeyBlockPem := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}))
+
+	testCases := []struct {
+		name          string
+		parse         func(string) error
+		keyPem        string
+		expectedFound string
+		expectedErr   error
+	}{
+		{
+			name:          "GIVEN_autoPriKeyParser_WHEN_parse_abc_string_THEN_return_error",
+			parse:         parseAutoPrivateKey(autoPriKeyParser),
+			keyPem:        "abc",
+			expectedFound: "no PEM block",
+			expectedErr:   ErrWrongPEMType,
+		},
+		{
+			name:          "GIVEN_autoPriKeyParser_WHEN_parse_ec_pkcs8_THEN_return_error",
+			parse:         parseAutoPrivateKey(autoPriKeyParser),
+			keyPem:        ecPkcs8Pem,
+			expectedFound: "PKCS #8 ECDSA private key",
+			expectedErr:   ErrNotRSAKey,
+		},
+		{
+			name:          "GIVEN_autoPriKeyParser_WHEN_parse_pkixPublicKeyPem_THEN_return_error",
+			parse:         parseAutoPrivateKey(autoPriKeyParser),
+			keyPem:        pkixPubKeyPem,
+			expectedFound: "PUBLIC KEY block",
+			expectedErr:   ErrWrongPEMType,
+		},
+		{
+			name:          "GIVEN_autoPriKeyParser_without_passphrase_WHEN_parse_encrypted_pkcs8_THEN_return_error",
+			parse:         parseAutoPrivateKey(&AutoPrivateKeyParser{}),
+			keyPem:        pbkdf2EncryptedPriKeyPem,
+			expectedFound: "encrypted PKCS #8 private key",
+		},
+		{
+			name:          "GIVEN_autoPubKeyParser_WHEN_parse_abc_string_THEN_return_error",
+			parse:         parseAutoPublicKey,
+			keyPem:        "abc",
+			expectedFound: "no PEM block",
+			expectedErr:   ErrWrongPEMType,
+		},
+		{
+			name:          "GIVEN_autoPubKeyParser_WHEN_parse_ec_pkix_THEN_return_error",
+			parse:         parseAutoPublicKey,
+			keyPem:        ecPkixPem,
+			expectedFound: "PKIX ECDSA public key",
+			expectedErr:   ErrNotRSAKey,
+		},
+		{
+			name:          "GIVEN_autoPubKeyParser_WHEN_parse_ec_certificate_THEN_return_error",
+			parse:         parseAutoPublicKey,
+			keyPem:        ecCertPem,
+			expectedFound: "X.509 certificate with ECDSA public key",
+			expectedErr:   ErrNotRSAKey,
+		},
+		{
+			name:          "GIVEN_autoPubKeyParser_WHEN_parse_pkcs1PrivateKeyPem_THEN_return_error",
+			parse:         parseAutoPublicKey,
+			keyPem:        pkcs1PriKeyPem,
+			expectedFound: "RSA PRIVATE KEY block",
+			expectedErr:   ErrWrongPEMType,
+		},
+		{
+			name:          "GIVEN_autoPriKeyParser_WHEN_parse_pkix_public_key_in_private_key_block_THEN_return_error",
+			parse:         parseAutoPrivateKey(autoPriKeyParser),
+			keyPem:        rsaPkixInPrivateKeyBlockPem,
+			expectedFound: "PKIX RSA public key",
+			expectedErr:   ErrWrongPEMType,
+		},
+		{
+			name:          "GIVEN_autoPriKeyParser_with_wrong_passphrase_WHEN_parse_encrypted_pkcs8_THEN_return_error",
+			parse:         parseAutoPrivateKey(&AutoPrivateKeyParser{Passphrase: []byte("wrong passphrase")}),
+			keyPem:        pbkdf2EncryptedPriKeyPem,
+			expectedFound: "encrypted PKCS #8 private key",
+		},
+		{
+			name:          "GIVEN_autoPriKeyParser_WHEN_parse_ec_jwk_THEN_return_error",
+			parse:         parseAutoPrivateKey(autoPriKeyParser),
+			keyPem:        `{"kty":"EC"}`,
+			expectedFound: "JWK",
+			expectedErr:   ErrNotRSAKey,
+		},
+		{
+			name:          "GIVEN_autoPubKeyParser_WHEN_parse_pkcs1_private_key_in_public_key_block_THEN_return_error",
+			parse:         parseAutoPublicKey,
+			keyPem:        rsaPkcs1InPublicKeyBlockPem,
+			expectedFound: "PKCS #1 RSA private key",
+			expectedErr:   ErrWrongPEMType,
+		},
+		{
+			name:          "GIVEN_autoPubKeyParser_WHEN_parse_ec_jwk_THEN_return_error",
+			parse:         parseAutoPublicKey,
+			keyPem:        `{"kty":"EC"}`,
+			expectedFound: "JWK",
+			expectedErr:   ErrNotRSAKey,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.parse(tc.keyPem)
+
+			var formatErr *KeyFormatError
+			assert.True(t, errors.As(err, &formatErr), "expected KeyFormatError, got %v", err)
+			assert.Equal(t, tc.expectedFound, formatErr.Found)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestAutoKeyParserMarshal(t *testing.T) {
+	privateKey, _ := pkcs1PriKeyParser.Parse(pkcs1PriKeyPem)
+
+	t.Run("GIVEN_autoPriKeyParser_WHEN_marshal_THEN_use_pkcs8", func(t *testing.T) {
+		priKeyPem, err := autoPriKeyParser.Marshal(privateKey)
+		assert.NoError(t, err)
+
+		key, err := pkcs8PriKeyParser.Parse(priKeyPem)
+		assert.NoError(t, err)
+		assert.True(t, privateKey.Equal(key))
+	})
+
+	t.Run("GIVEN_autoPubKeyParser_with_marshaler_WHEN_marshal_THEN_use_marshaler", func(t *testing.T) {
+		pubKeyPem, err := (&AutoPublicKeyParser{Marshaler: pkcs1PubKeyParser}).Marshal(&privateKey.PublicKey)
+		assert.NoError(t, err)
+
+		key, err := pkcs1PubKeyParser.Parse(pubKeyPem)
+		assert.NoError(t, err)
+		assert.True(t, privateKey.PublicKey.Equal(key))
+	})
+}
+
+func newCertificatePem(t *testing.T, publicKey, privateKey interface{}) string {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Unix(0, 0),
+		NotAfter:     time.Unix(0, 0).Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, publicKey, privateKey)
+	assert.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func parseAutoPrivateKey(parser *AutoPrivateKeyParser) func(string) error {
+	return func(keyPem string) error {
+		_, err := parser.Parse(keyPem)
+		return err
+	}
+}
+
+func parseAutoPublicKey(keyPem string) error {
+	_, err := autoPubKeyParser.Parse(keyPem)
+	return err
+}
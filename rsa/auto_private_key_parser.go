@@ -0,0 +1,96 @@
+package rsa
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	autoPrivateKey = "RSA private key"
+)
+
+// AutoPrivateKeyParser parses RSA private keys in any supported format,
+// detected from the PEM block type and DER structure: PKCS #1, PKCS #8,
+// encrypted PKCS #8 (when Passphrase is set) and JWK.
+type AutoPrivateKeyParser struct {
+	Passphrase []byte           // passphrase of encrypted PKCS #8 keys.
+	Marshaler  PrivateKeyParser // used by Marshal, Pkcs8PrivateKeyParser if nil.
+}
+
+// Marshal *rsa.PrivateKey with Marshaler.
+func (p *AutoPrivateKeyParser) Marshal(privateKey *rsa.PrivateKey) (string, error) {
+	marshaler := p.Marshaler
+	if marshaler == nil {
+		marshaler = &Pkcs8PrivateKeyParser{}
+	}
+	return marshaler.Marshal(privateKey)
+}
+
+// Parse an RSA private key in any supported format.
+func (p *AutoPrivateKeyParser) Parse(privateKeyPem string) (*rsa.PrivateKey, error) {
+	if strings.HasPrefix(strings.TrimSpace(privateKeyPem), "{") {
+		privateKey, err := (&JwkPrivateKeyParser{}).Parse(privateKeyPem)
+		if err != nil {
+			return nil, &KeyFormatError{Op: "parse", Expected: autoPrivateKey, Found: "JWK", Err: err}
+		}
+		return privateKey, nil
+	}
+
+	block, _ := pem.Decode([]byte(privateKeyPem))
+	if block == nil {
+		return nil, &KeyFormatError{Op: "decode", Expected: autoPrivateKey, Found: "no PEM block", Err: ErrWrongPEMType}
+	}
+
+	parseErr := func(found string, err error) error {
+		return &KeyFormatError{Op: "parse", Expected: autoPrivateKey, BlockType: block.Type, Found: found, Err: err}
+	}
+
+	if _, ok := block.Headers["DEK-Info"]; ok {
+		return nil, parseErr("legacy encrypted PEM", errors.New("unsupported encryption, convert to encrypted PKCS #8"))
+	}
+
+	switch block.Type {
+	case PEM_TYPE_ENCRYPTED_PRIVATE_KEY:
+		if p.Passphrase == nil {
+			return nil, parseErr("encrypted PKCS #8 private key", errors.New("no passphrase given"))
+		}
+		privateKey, err := NewPkcs8EncryptedPrivateKeyParser(p.Passphrase).Parse(privateKeyPem)
+		if err != nil {
+			return nil, parseErr("encrypted PKCS #8 private key", err)
+		}
+		return privateKey, nil
+	case "CERTIFICATE":
+		return nil, parseErr("X.509 certificate", ErrWrongPEMType)
+	case PEM_TYPE_PKIX_PUBLIC_KEY, PEM_TYPE_PKCS1_PUBLIC_KEY:
+		return nil, parseErr(fmt.Sprintf("%s block", block.Type), ErrWrongPEMType)
+	}
+
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if privateKey, ok := key.(*rsa.PrivateKey); ok {
+			return privateKey, nil
+		}
+		return nil, parseErr(fmt.Sprintf("PKCS #8 %s private key", keyTypeName(key)), ErrNotRSAKey)
+	}
+
+	if _, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return nil, parseErr("SEC 1 EC private key", ErrNotRSAKey)
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return nil, parseErr(fmt.Sprintf("PKIX %s public key", keyTypeName(key)), ErrWrongPEMType)
+	}
+
+	if _, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return nil, parseErr("PKCS #1 RSA public key", ErrWrongPEMType)
+	}
+
+	return nil, parseErr(fmt.Sprintf("unrecognized %s block", block.Type), ErrWrongPEMType)
+}
@@ -0,0 +1,86 @@
+package rsa
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+const (
+	autoPublicKey = "RSA public key"
+)
+
+// AutoPublicKeyParser parses RSA public keys in any supported format,
+// detected from the PEM block type and DER structure: PKCS #1, PKIX,
+// X.509 certificates and JWK.
+type AutoPublicKeyParser struct {
+	Marshaler PublicKeyParser // used by Marshal, PkixPublicKeyParser if nil.
+}
+
+// Marshal *rsa.PublicKey with Marshaler.
+func (p *AutoPublicKeyParser) Marshal(publicKey *rsa.PublicKey) (string, error) {
+	marshaler := p.Marshaler
+	if marshaler == nil {
+		marshaler = &PkixPublicKeyParser{}
+	}
+	return marshaler.Marshal(publicKey)
+}
+
+// Parse an RSA public key in any supported format.
+func (p *AutoPublicKeyParser) Parse(publicKeyPem string) (*rsa.PublicKey, error) {
+	if strings.HasPrefix(strings.TrimSpace(publicKeyPem), "{") {
+		publicKey, err := (&JwkPublicKeyParser{}).Parse(publicKeyPem)
+		if err != nil {
+			return nil, &KeyFormatError{Op: "parse", Expected: autoPublicKey, Found: "JWK", Err: err}
+		}
+		return publicKey, nil
+	}
+
+	block, _ := pem.Decode([]byte(publicKeyPem))
+	if block == nil {
+		return nil, &KeyFormatError{Op: "decode", Expected: autoPublicKey, Found: "no PEM block", Err: ErrWrongPEMType}
+	}
+
+	parseErr := func(found string, err error) error {
+		return &KeyFormatError{Op: "parse", Expected: autoPublicKey, BlockType: block.Type, Found: found, Err: err}
+	}
+
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, parseErr("invalid X.509 certificate", err)
+		}
+
+		publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			return nil, parseErr(fmt.Sprintf("X.509 certificate with %s public key", keyTypeName(cert.PublicKey)), ErrNotRSAKey)
+		}
+		return publicKey, nil
+	case PEM_TYPE_PKCS8_PRIVATE_KEY, PEM_TYPE_PKCS1_PRIVATE_KEY, "EC PRIVATE KEY", PEM_TYPE_ENCRYPTED_PRIVATE_KEY:
+		return nil, parseErr(fmt.Sprintf("%s block", block.Type), ErrWrongPEMType)
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		if publicKey, ok := key.(*rsa.PublicKey); ok {
+			return publicKey, nil
+		}
+		return nil, parseErr(fmt.Sprintf("PKIX %s public key", keyTypeName(key)), ErrNotRSAKey)
+	}
+
+	if publicKey, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return publicKey, nil
+	}
+
+	if _, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return nil, parseErr("PKCS #1 RSA private key", ErrWrongPEMType)
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return nil, parseErr(fmt.Sprintf("PKCS #8 %s private key", keyTypeName(key)), ErrWrongPEMType)
+	}
+
+	return nil, parseErr(fmt.Sprintf("unrecognized %s block", block.Type), ErrWrongPEMType)
+}
@@ -0,0 +1,9 @@
+package rsa
+
+type Encrypter interface {
+	Encrypt(string) (string, error)
+}
+
+type Decrypter interface {
+	Decrypt(string) (string, error)
+}
@@ -0,0 +1,190 @@
+package rsa
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	JWK_KEY_TYPE = "RSA"
+)
+
+// Jwk is a JSON Web Key (RFC 7517) holding an RSA public or private key.
+type Jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	D  string `json:"d,omitempty"`
+	P  string `json:"p,omitempty"`
+	Q  string `json:"q,omitempty"`
+	Dp string `json:"dp,omitempty"`
+	Dq string `json:"dq,omitempty"`
+	Qi string `json:"qi,omitempty"`
+
+	Oth []json.RawMessage `json:"oth,omitempty"`
+}
+
+// NewPublicJwk creates Jwk from *rsa.PublicKey.
+func NewPublicJwk(publicKey *rsa.PublicKey) Jwk {
+	return Jwk{
+		Kty: JWK_KEY_TYPE,
+		N:   encodeJwkInt(publicKey.N),
+		E:   encodeJwkInt(big.NewInt(int64(publicKey.E))),
+	}
+}
+
+// NewPrivateJwk creates Jwk from *rsa.PrivateKey, computing its CRT
+// values without modifying privateKey.
+func NewPrivateJwk(privateKey *rsa.PrivateKey) (Jwk, error) {
+	if len(privateKey.Primes) != 2 {
+		return Jwk{}, errors.New("multi-prime rsa private key is not supported")
+	}
+
+	p, q := privateKey.Primes[0], privateKey.Primes[1]
+	one := big.NewInt(1)
+
+	dp := new(big.Int).Mod(privateKey.D, new(big.Int).Sub(p, one))
+	dq := new(big.Int).Mod(privateKey.D, new(big.Int).Sub(q, one))
+	qinv := new(big.Int).ModInverse(q, p)
+	if qinv == nil {
+		return Jwk{}, errors.New("invalid rsa private key primes")
+	}
+
+	jwk := NewPublicJwk(&privateKey.PublicKey)
+	jwk.D = encodeJwkInt(privateKey.D)
+	jwk.P = encodeJwkInt(p)
+	jwk.Q = encodeJwkInt(q)
+	jwk.Dp = encodeJwkInt(dp)
+	jwk.Dq = encodeJwkInt(dq)
+	jwk.Qi = encodeJwkInt(qinv)
+
+	return jwk, nil
+}
+
+// PublicKey returns the RSA public key of the Jwk.
+func (j Jwk) PublicKey() (*rsa.PublicKey, error) {
+	if j.Kty != JWK_KEY_TYPE {
+		return nil, fmt.Errorf("unsupported kty %q: %w", j.Kty, ErrNotRSAKey)
+	}
+
+	n, err := decodeJwkInt("n", j.N)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := decodeJwkInt("e", j.E)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsInt64() || e.Int64() < 2 || e.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid e")
+	}
+
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+// PrivateKey returns the RSA private key of the Jwk.
+func (j Jwk) PrivateKey() (*rsa.PrivateKey, error) {
+	publicKey, err := j.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(j.Oth) > 0 {
+		return nil, errors.New("multi-prime rsa private key is not supported")
+	}
+
+	d, err := decodeJwkInt("d", j.D)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := decodeJwkInt("p", j.P)
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := decodeJwkInt("q", j.Q)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey := &rsa.PrivateKey{
+		PublicKey: *publicKey,
+		D:         d,
+		Primes:    []*big.Int{p, q},
+	}
+
+	err = privateKey.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey.Precompute()
+
+	return privateKey, nil
+}
+
+// Thumbprint computes the RFC 7638 thumbprint of the Jwk with the
+// crypto hash given, encoded in base64url.
+func (j Jwk) Thumbprint(hash crypto.Hash) (string, error) {
+	publicKey, err := j.PublicKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to compute JWK thumbprint: %w", err)
+	}
+
+	if !hash.Available() {
+		return "", errors.New(ERR_HASH_UNAVAILABLE)
+	}
+
+	// Re-encode members from the key, as j may hold padded or
+	// zero-prefixed values that parse to the same key.
+	canonicalJwk := NewPublicJwk(publicKey)
+
+	// Required members in lexicographic order, without whitespace.
+	canonical := fmt.Sprintf(
+		`{"e":"%s","kty":"%s","n":"%s"}`,
+		canonicalJwk.E,
+		canonicalJwk.Kty,
+		canonicalJwk.N,
+	)
+
+	hasher := hash.New()
+	hasher.Write([]byte(canonical))
+
+	return base64.RawURLEncoding.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// Thumbprint computes the RFC 7638 thumbprint of an RSA public key with
+// the crypto hash given, encoded in base64url.
+func Thumbprint(publicKey *rsa.PublicKey, hash crypto.Hash) (string, error) {
+	return NewPublicJwk(publicKey).Thumbprint(hash)
+}
+
+func encodeJwkInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func decodeJwkInt(name, value string) (*big.Int, error) {
+	if value == "" {
+		return nil, fmt.Errorf("missing %s", name)
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,47 @@
+package rsa
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+)
+
+type JwkPrivateKeyParser struct {
+	KeyID string // kid of marshaled keys, omitted if empty.
+	Use   string // use of marshaled keys, omitted if empty.
+	Alg   string // alg of marshaled keys, omitted if empty.
+}
+
+// Marshal *rsa.PrivateKey to JWK.
+func (p *JwkPrivateKeyParser) Marshal(privateKey *rsa.PrivateKey) (string, error) {
+	jwk, err := NewPrivateJwk(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal private key to JWK: %w", err)
+	}
+	jwk.Kid = p.KeyID
+	jwk.Use = p.Use
+	jwk.Alg = p.Alg
+
+	jwkBytes, err := json.Marshal(jwk)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal private key to JWK: %w", err)
+	}
+
+	return string(jwkBytes), nil
+}
+
+// Parse an RSA private key JWK.
+func (p *JwkPrivateKeyParser) Parse(privateKeyJwk string) (*rsa.PrivateKey, error) {
+	var jwk Jwk
+	err := json.Unmarshal([]byte(privateKeyJwk), &jwk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JWK private key: %w", err)
+	}
+
+	privateKey, err := jwk.PrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWK private key: %w", err)
+	}
+
+	return privateKey, nil
+}
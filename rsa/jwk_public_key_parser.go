@@ -0,0 +1,44 @@
+package rsa
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+)
+
+type JwkPublicKeyParser struct {
+	KeyID string // kid of marshaled keys, omitted if empty.
+	Use   string // use of marshaled keys, omitted if empty.
+	Alg   string // alg of marshaled keys, omitted if empty.
+}
+
+// Marshal *rsa.PublicKey to JWK.
+func (p *JwkPublicKeyParser) Marshal(publicKey *rsa.PublicKey) (string, error) {
+	jwk := NewPublicJwk(publicKey)
+	jwk.Kid = p.KeyID
+	jwk.Use = p.Use
+	jwk.Alg = p.Alg
+
+	jwkBytes, err := json.Marshal(jwk)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal public key to JWK: %w", err)
+	}
+
+	return string(jwkBytes), nil
+}
+
+// Parse an RSA public key JWK. Private members, if any, are ignored.
+func (p *JwkPublicKeyParser) Parse(publicKeyJwk string) (*rsa.PublicKey, error) {
+	var jwk Jwk
+	err := json.Unmarshal([]byte(publicKeyJwk), &jwk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JWK public key: %w", err)
+	}
+
+	publicKey, err := jwk.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWK public key: %w", err)
+	}
+
+	return publicKey, nil
+}
@@ -0,0 +1,243 @@
+package rsa
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Example of RFC 7638, section 3.1.
+const (
+	rfc7638Jwk = `{
+		"kty": "RSA",
+		"n": "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		"e": "AQAB",
+		"alg": "RS256",
+		"kid": "2011-04-29"
+	}`
+	rfc7638Thumbprint = "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+)
+
+var (
+	jwkPriKeyParser = &JwkPrivateKeyParser{KeyID: "key-1", Use: "sig", Alg: "RS256"}
+	jwkPubKeyParser = &JwkPublicKeyParser{KeyID: "key-1", Use: "sig", Alg: "RS256"}
+)
+
+func TestJwkRoundTrip(t *testing.T) {
+	privateKey, _ := pkcs1PriKeyParser.Parse(pkcs1PriKeyPem)
+
+	t.Run("GIVEN_jwkPriKeyParser_WHEN_marshal_and_parse_private_key_THEN_return_same_key", func(t *testing.T) {
+		priKeyJwk, err := jwkPriKeyParser.Marshal(privateKey)
+		assert.NoError(t, err)
+
+		var jwk Jwk
+		assert.NoError(t, json.Unmarshal([]byte(priKeyJwk), &jwk))
+		assert.Equal(t, "key-1", jwk.Kid)
+		assert.Equal(t, "sig", jwk.Use)
+		assert.Equal(t, "RS256", jwk.Alg)
+
+		key, err := jwkPriKeyParser.Parse(priKeyJwk)
+		assert.NoError(t, err)
+		assert.True(t, privateKey.Equal(key))
+	})
+
+	t.Run("GIVEN_jwkPubKeyParser_WHEN_marshal_and_parse_public_key_THEN_return_same_key", func(t *testing.T) {
+		pubKeyJwk, err := jwkPubKeyParser.Marshal(&privateKey.PublicKey)
+		assert.NoError(t, err)
+		assert.NotContains(t, pubKeyJwk, `"d"`)
+
+		key, err := jwkPubKeyParser.Parse(pubKeyJwk)
+		assert.NoError(t, err)
+		assert.True(t, privateKey.PublicKey.Equal(key))
+	})
+
+	t.Run("GIVEN_jwkPubKeyParser_WHEN_parse_private_key_jwk_THEN_return_public_key", func(t *testing.T) {
+		priKeyJwk, _ := jwkPriKeyParser.Marshal(privateKey)
+
+		key, err := jwkPubKeyParser.Parse(priKeyJwk)
+		assert.NoError(t, err)
+		assert.True(t, privateKey.PublicKey.Equal(key))
+	})
+}
+
+func TestNewPrivateJwkDoesNotModifyKey(t *testing.T) {
+	privateKey, _ := pkcs1PriKeyParser.Parse(pkcs1PriKeyPem)
+	bareKey := &rsa.PrivateKey{PublicKey: privateKey.PublicKey, D: privateKey.D, Primes: privateKey.Primes}
+
+	jwk, err := NewPrivateJwk(bareKey)
+
+	assert.NoError(t, err)
+	assert.Nil(t, bareKey.Precomputed.Dp)
+	assert.Equal(t, encodeJwkInt(privateKey.Precomputed.Dp), jwk.Dp)
+	assert.Equal(t, encodeJwkInt(privateKey.Precomputed.Dq), jwk.Dq)
+	assert.Equal(t, encodeJwkInt(privateKey.Precomputed.Qinv), jwk.Qi)
+}
+
+func TestJwkThumbprint(t *testing.T) {
+	publicKey, err := jwkPubKeyParser.Parse(rfc7638Jwk)
+	assert.NoError(t, err)
+
+	thumbprint, err := Thumbprint(publicKey, crypto.SHA256)
+	assert.NoError(t, err)
+	assert.Equal(t, rfc7638Thumbprint, thumbprint)
+}
+
+func TestJwkThumbprintOfNonCanonicalMembers(t *testing.T) {
+	var jwk Jwk
+	assert.NoError(t, json.Unmarshal([]byte(rfc7638Jwk), &jwk))
+
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		n    string
+		e    string
+	}{
+		{
+			name: "GIVEN_padded_members_WHEN_computing_thumbprint_THEN_return_thumbprint_of_key",
+			n:    base64.URLEncoding.EncodeToString(append([]byte{0}, n...)),
+			e:    "AQAB",
+		},
+		{
+			name: "GIVEN_zero_prefixed_members_WHEN_computing_thumbprint_THEN_return_thumbprint_of_key",
+			n:    base64.RawURLEncoding.EncodeToString(append([]byte{0, 0}, n...)),
+			e:    "AAEAAQ==",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jwk.N = tc.n
+			jwk.E = tc.e
+
+			thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+
+			assert.NoError(t, err)
+			assert.Equal(t, rfc7638Thumbprint, thumbprint)
+		})
+	}
+}
+
+func TestFailedJwkParsing(t *testing.T) {
+	publicKey, _ := pkcs1PubKeyParser.Parse(pkcs1PubKeyPem)
+	pubKeyJwk, _ := jwkPubKeyParser.Marshal(publicKey)
+
+	testCases := []struct {
+		name           string
+		parse          func(string) error
+		keyJwk         string
+		expectedErrMsg string
+	}{
+		{
+			name:           "GIVEN_jwkPriKeyParser_WHEN_parse_public_key_jwk_THEN_return_error",
+			parse:          parsePrivateJwk,
+			keyJwk:         pubKeyJwk,
+			expectedErrMsg: "failed to parse JWK private key: missing d",
+		},
+		{
+			name:           "GIVEN_jwkPriKeyParser_WHEN_parse_pem_THEN_return_error",
+			parse:          parsePrivateJwk,
+			keyJwk:         pkcs1PriKeyPem,
+			expectedErrMsg: "failed to decode JWK private key",
+		},
+		{
+			name:           "GIVEN_jwkPubKeyParser_WHEN_parse_ec_jwk_THEN_return_error",
+			parse:          parsePublicJwk,
+			keyJwk:         `{"kty":"EC","crv":"P-256","x":"AQAB","y":"AQAB"}`,
+			expectedErrMsg: "failed to parse JWK public key: unsupported kty",
+		},
+		{
+			name:           "GIVEN_jwkPubKeyParser_WHEN_parse_jwk_without_n_THEN_return_error",
+			parse:          parsePublicJwk,
+			keyJwk:         `{"kty":"RSA","e":"AQAB"}`,
+			expectedErrMsg: "failed to parse JWK public key: missing n",
+		},
+		{
+			name:           "GIVEN_jwkPubKeyParser_WHEN_parse_jwk_with_invalid_base64url_THEN_return_error",
+			parse:          parsePublicJwk,
+			keyJwk:         `{"kty":"RSA","n":"!!","e":"AQAB"}`,
+			expectedErrMsg: "failed to parse JWK public key: invalid n",
+		},
+		{
+			name:           "GIVEN_jwkPubKeyParser_WHEN_parse_abc_string_THEN_return_error",
+			parse:          parsePublicJwk,
+			keyJwk:         "abc",
+			expectedErrMsg: "failed to decode JWK public key",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.parse(tc.keyJwk)
+
+			assert.ErrorContainsf(
+				t,
+				err,
+				tc.expectedErrMsg,
+				"expected error containing %q, got %s", tc.expectedErrMsg, err,
+			)
+		})
+	}
+}
+
+func TestJwks(t *testing.T) {
+	pkcs1PubKey, _ := pkcs1PubKeyParser.Parse(pkcs1PubKeyPem)
+	pkixPubKey, _ := pkixPubKeyParser.Parse(pkixPubKeyPem)
+
+	jwksJson, err := NewJwks(map[string]*rsa.PublicKey{
+		"key-2": pkixPubKey,
+		"key-1": pkcs1PubKey,
+	}).Marshal()
+	assert.NoError(t, err)
+
+	jwks, err := ParseJwks(jwksJson)
+	assert.NoError(t, err)
+	assert.Equal(t, "key-1", jwks.Keys[0].Kid)
+	assert.Equal(t, "key-2", jwks.Keys[1].Kid)
+
+	t.Run("GIVEN_kid_in_jwks_WHEN_lookup_public_key_THEN_return_key", func(t *testing.T) {
+		key, err := jwks.PublicKey("key-2")
+
+		assert.NoError(t, err)
+		assert.True(t, pkixPubKey.Equal(key))
+	})
+
+	t.Run("GIVEN_kid_not_in_jwks_WHEN_lookup_public_key_THEN_return_error", func(t *testing.T) {
+		key, err := jwks.PublicKey("key-3")
+
+		assert.Nil(t, key)
+		assert.ErrorContains(t, err, "failed to find JWK")
+	})
+
+	t.Run("GIVEN_jwks_with_ec_key_WHEN_parsing_THEN_keep_key_but_fail_rsa_lookup", func(t *testing.T) {
+		mixedJwks, err := ParseJwks(`{"keys":[{"kty":"EC","kid":"ec","crv":"P-256","x":"AQAB","y":"AQAB"},` + rfc7638Jwk + `]}`)
+		assert.NoError(t, err)
+
+		_, err = mixedJwks.PublicKey("2011-04-29")
+		assert.NoError(t, err)
+
+		_, err = mixedJwks.PublicKey("ec")
+		assert.ErrorContains(t, err, "unsupported kty")
+	})
+
+	t.Run("GIVEN_invalid_json_WHEN_parsing_jwks_THEN_return_error", func(t *testing.T) {
+		_, err := ParseJwks("abc")
+
+		assert.ErrorContains(t, err, "failed to decode JWKS")
+	})
+}
+
+func parsePrivateJwk(keyJwk string) error {
+	_, err := jwkPriKeyParser.Parse(keyJwk)
+	return err
+}
+
+func parsePublicJwk(keyJwk string) error {
+	_, err := jwkPubKeyParser.Parse(keyJwk)
+	return err
+}
@@ -0,0 +1,79 @@
+package rsa
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Jwks is a JSON Web Key Set (RFC 7517). It may contain keys of types
+// other than RSA, which are kept but cannot be looked up as RSA keys.
+type Jwks struct {
+	Keys []Jwk `json:"keys"`
+}
+
+// NewJwks creates Jwks publishing the RSA public keys given by kid,
+// sorted by kid.
+func NewJwks(publicKeys map[string]*rsa.PublicKey) Jwks {
+	kids := make([]string, 0, len(publicKeys))
+	for kid := range publicKeys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	jwks := Jwks{Keys: make([]Jwk, 0, len(publicKeys))}
+	for _, kid := range kids {
+		jwk := NewPublicJwk(publicKeys[kid])
+		jwk.Kid = kid
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
+
+// ParseJwks parses a JWK set.
+func ParseJwks(jwksJson string) (Jwks, error) {
+	var jwks Jwks
+	err := json.Unmarshal([]byte(jwksJson), &jwks)
+	if err != nil {
+		return Jwks{}, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	return jwks, nil
+}
+
+// Marshal Jwks to JSON.
+func (s Jwks) Marshal() (string, error) {
+	jwksBytes, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWKS: %w", err)
+	}
+
+	return string(jwksBytes), nil
+}
+
+// Key returns the JWK of kid.
+func (s Jwks) Key(kid string) (Jwk, error) {
+	for _, jwk := range s.Keys {
+		if jwk.Kid == kid {
+			return jwk, nil
+		}
+	}
+
+	return Jwk{}, fmt.Errorf("failed to find JWK of kid %q", kid)
+}
+
+// PublicKey returns the RSA public key of kid.
+func (s Jwks) PublicKey(kid string) (*rsa.PublicKey, error) {
+	jwk, err := s.Key(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := jwk.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWK of kid %q: %w", kid, err)
+	}
+
+	return publicKey, nil
+}
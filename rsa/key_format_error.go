@@ -0,0 +1,45 @@
+package rsa
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+)
+
+// KeyFormatError is returned by the auto-detecting key parsers when the
+// input is not an RSA key in a format they can parse. It describes what
+// was found instead.
+type KeyFormatError struct {
+	Op        string // "decode" or "parse"
+	Expected  string // e.g. "RSA private key"
+	BlockType string // PEM block type, empty if none was found
+	Found     string // description of what was found
+	Err       error  // ErrWrongPEMType, ErrNotRSAKey, or the error of the format's parser
+}
+
+func (e *KeyFormatError) Error() string {
+	msg := fmt.Sprintf("failed to %s %s: found %s", e.Op, e.Expected, e.Found)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *KeyFormatError) Unwrap() error {
+	return e.Err
+}
+
+// keyTypeName describes the type of a key returned by crypto/x509.
+func keyTypeName(key interface{}) string {
+	switch key.(type) {
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return "RSA"
+	case *ecdsa.PrivateKey, *ecdsa.PublicKey:
+		return "ECDSA"
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return "Ed25519"
+	default:
+		return fmt.Sprintf("%T", key)
+	}
+}
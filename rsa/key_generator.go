@@ -3,10 +3,15 @@ package rsa
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"fmt"
 )
 
 const (
-	keySize = 2048
+	DefaultKeySize = 2048
+	MinKeySize     = 2048
+	// MaxKeySize bounds the time GenKey spends searching for primes, and
+	// matches the largest RSA key crypto/tls accepts.
+	MaxKeySize = 8192
 )
 
 // NewPkcs1KeysGenerator creates rsa keysGenerator which
@@ -58,6 +63,7 @@ func NewPkcs8PkixKeysGenerator() *keysGenerator {
 }
 
 type keysGenerator struct {
+	keySize      int
 	priKeyParser PrivateKeyParser
 	pubKeyParser PublicKeyParser
 }
@@ -65,7 +71,9 @@ type keysGenerator struct {
 func NewKeysGenerator(
 	options ...func(*keysGenerator),
 ) *keysGenerator {
-	g := &keysGenerator{}
+	g := &keysGenerator{
+		keySize: DefaultKeySize,
+	}
 	for _, o := range options {
 		o(g)
 	}
@@ -88,18 +96,52 @@ func WithPublicKeyParser(
 	}
 }
 
-// GenKeyPair generates 2048 RSA private and public key pair as pem strings
+// WithKeySize sets the size of generated keys in bits,
+// which must be between MinKeySize and MaxKeySize. Defaults to
+// DefaultKeySize.
+func WithKeySize(
+	keySize int,
+) func(*keysGenerator) {
+	return func(g *keysGenerator) {
+		g.keySize = keySize
+	}
+}
+
+// GenKeyPair generates RSA private and public key pair as pem strings
 func (g *keysGenerator) GenKeyPair() (privateKeyPem, publicKeyPem string, err error) {
-	privateKey, err := rsa.GenerateKey(rand.Reader, keySize)
+	privateKey, err := g.GenKey()
 	if err != nil {
 		return
 	}
 
-	err = privateKey.Validate()
+	return g.MarshalKeyPair(privateKey)
+}
+
+// GenKey generates RSA private key with public exponent 65537
+func (g *keysGenerator) GenKey() (privateKey *rsa.PrivateKey, err error) {
+	if g.keySize < MinKeySize {
+		return nil, fmt.Errorf("key size %d is less than the minimum of %d", g.keySize, MinKeySize)
+	}
+
+	if g.keySize > MaxKeySize {
+		return nil, fmt.Errorf("key size %d is greater than the maximum of %d", g.keySize, MaxKeySize)
+	}
+
+	privateKey, err = rsa.GenerateKey(rand.Reader, g.keySize)
 	if err != nil {
 		return
 	}
 
+	err = privateKey.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// MarshalKeyPair marshals RSA private key and its public key as pem strings
+func (g *keysGenerator) MarshalKeyPair(privateKey *rsa.PrivateKey) (privateKeyPem, publicKeyPem string, err error) {
 	privateKeyBytes, err := g.priKeyParser.Marshal(privateKey)
 	if err != nil {
 		return
@@ -62,3 +62,72 @@ func TestGenKeyPair(t *testing.T) {
 		})
 	}
 }
+
+func TestGenKeyWithOptions(t *testing.T) {
+
+	t.Run("GIVEN_default_options_WHEN_generate_key_THEN_return_2048_bits_key", func(t *testing.T) {
+		key, err := NewPkcs1KeysGenerator().GenKey()
+
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultKeySize, key.N.BitLen())
+		assert.Equal(t, 65537, key.E)
+	})
+
+	t.Run("GIVEN_key_size_3072_WHEN_generate_key_pair_THEN_return_3072_bits_key", func(t *testing.T) {
+		keyGen := NewKeysGenerator(
+			WithKeySize(3072),
+			WithPrivateKeyParser(&Pkcs8PrivateKeyParser{}),
+			WithPublicKeyParser(&PkixPublicKeyParser{}),
+		)
+
+		key, err := keyGen.GenKey()
+		assert.NoError(t, err)
+		assert.Equal(t, 3072, key.N.BitLen())
+
+		priKey, pubKey, err := keyGen.MarshalKeyPair(key)
+		assert.NoError(t, err)
+
+		parsedPriKey, err := keyGen.priKeyParser.Parse(priKey)
+		assert.NoError(t, err)
+		assert.True(t, key.Equal(parsedPriKey))
+
+		parsedPubKey, err := keyGen.pubKeyParser.Parse(pubKey)
+		assert.NoError(t, err)
+		assert.True(t, key.PublicKey.Equal(parsedPubKey))
+	})
+}
+
+func TestGenKeyWithInvalidOptionsShouldThrowError(t *testing.T) {
+
+	testCases := []struct {
+		name           string
+		keyGen         *keysGenerator
+		expectedErrMsg string
+	}{
+		{
+			name:           "GIVEN_key_size_1024_WHEN_generate_key_pair_THEN_return_error",
+			keyGen:         NewKeysGenerator(WithKeySize(1024)),
+			expectedErrMsg: "less than the minimum",
+		},
+		{
+			name:           "GIVEN_key_size_16384_WHEN_generate_key_pair_THEN_return_error",
+			keyGen:         NewKeysGenerator(WithKeySize(16384)),
+			expectedErrMsg: "greater than the maximum",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			priKey, pubKey, err := tc.keyGen.GenKeyPair()
+
+			assert.Empty(t, priKey)
+			assert.Empty(t, pubKey)
+			assert.ErrorContainsf(
+				t,
+				err,
+				tc.expectedErrMsg,
+				"expected error containing %q, got %s", tc.expectedErrMsg, err,
+			)
+		})
+	}
+}
@@ -1,7 +1,31 @@
 package rsa
 
 import (
+	"bytes"
 	"crypto/rsa"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+const (
+	PEM_TYPE_PKCS1_PRIVATE_KEY     = "RSA PRIVATE KEY"
+	PEM_TYPE_PKCS8_PRIVATE_KEY     = "PRIVATE KEY"
+	PEM_TYPE_ENCRYPTED_PRIVATE_KEY = "ENCRYPTED PRIVATE KEY"
+	PEM_TYPE_PKCS1_PUBLIC_KEY      = "RSA PUBLIC KEY"
+	PEM_TYPE_PKIX_PUBLIC_KEY       = "PUBLIC KEY"
+)
+
+var (
+	// ErrNotRSAKey is returned when a key is parsed but is not an RSA key.
+	ErrNotRSAKey = errors.New("not an RSA key")
+	// ErrWrongPEMType is returned in strict mode when the PEM block type
+	// does not match the format of the parser, and by the auto-detecting
+	// parsers when the input is not a PEM block of the expected key.
+	ErrWrongPEMType = errors.New("wrong PEM block type")
+	// ErrTrailingPEMData is returned in strict mode when there is data
+	// after the PEM block.
+	ErrTrailingPEMData = errors.New("trailing data after PEM block")
 )
 
 type PrivateKeyParser interface {
@@ -13,3 +37,15 @@ type PublicKeyParser interface {
 	Marshal(*rsa.PublicKey) (string, error)
 	Parse(publicKeyPem string) (*rsa.PublicKey, error)
 }
+
+// checkPemBlock checks the type of a decoded PEM block and that nothing
+// but whitespace follows it.
+func checkPemBlock(block *pem.Block, rest []byte, blockType string) error {
+	if block.Type != blockType {
+		return fmt.Errorf("%w: got %q, want %q", ErrWrongPEMType, block.Type, blockType)
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return ErrTrailingPEMData
+	}
+	return nil
+}
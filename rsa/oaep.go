@@ -0,0 +1,88 @@
+package rsa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+)
+
+// encryptOAEP encrypts msg with RSA-OAEP using separate hash functions for
+// the label digest and the MGF1 mask generation, which rsa.EncryptOAEP
+// does not support.
+func encryptOAEP(
+	hash crypto.Hash,
+	mgfHash crypto.Hash,
+	publicKey *rsa.PublicKey,
+	msg, label []byte,
+) ([]byte, error) {
+	if publicKey == nil || publicKey.N == nil {
+		return nil, errors.New("invalid rsa public key")
+	}
+
+	h := hash.New()
+	k := publicKey.Size()
+	if len(msg) > k-2*h.Size()-2 {
+		return nil, rsa.ErrMessageTooLong
+	}
+
+	h.Write(label)
+	lHash := h.Sum(nil)
+
+	em := make([]byte, k)
+	seed := em[1 : 1+h.Size()]
+	db := em[1+h.Size():]
+
+	copy(db[0:h.Size()], lHash)
+	db[len(db)-len(msg)-1] = 1
+	copy(db[len(db)-len(msg):], msg)
+
+	if _, err := io.ReadFull(rand.Reader, seed); err != nil {
+		return nil, err
+	}
+
+	mgf1XOR(db, mgfHash.New(), seed)
+	mgf1XOR(seed, mgfHash.New(), db)
+
+	m := new(big.Int).SetBytes(em)
+	c := new(big.Int).Exp(m, big.NewInt(int64(publicKey.E)), publicKey.N)
+
+	return c.FillBytes(make([]byte, k)), nil
+}
+
+// mgf1XOR XORs out with the MGF1 mask generated from seed.
+func mgf1XOR(out []byte, hash hash.Hash, seed []byte) {
+	var counter [4]byte
+	var digest []byte
+
+	done := 0
+	for done < len(out) {
+		hash.Write(seed)
+		hash.Write(counter[0:4])
+		digest = hash.Sum(digest[:0])
+		hash.Reset()
+
+		for i := 0; i < len(digest) && done < len(out); i++ {
+			out[done] ^= digest[i]
+			done++
+		}
+
+		incCounter(&counter)
+	}
+}
+
+func incCounter(c *[4]byte) {
+	if c[3]++; c[3] != 0 {
+		return
+	}
+	if c[2]++; c[2] != 0 {
+		return
+	}
+	if c[1]++; c[1] != 0 {
+		return
+	}
+	c[0]++
+}
@@ -0,0 +1,59 @@
+package rsa
+
+import (
+	"crypto/rsa"
+	"fmt"
+
+	textcoder "github.com/imylam/text-coder"
+)
+
+var _ Decrypter = oaepDecrypter{}
+
+type oaepDecrypter struct {
+	params         oaepParams
+	privateKey     *rsa.PrivateKey
+	plainTextCoder textcoder.Coder
+	cipherCoder    textcoder.Coder
+}
+
+// NewOaepDecrypter creates decrypter which decrypt cipher text
+// with RSA private key using RSA-OAEP, SHA256 by default.
+//
+// Implements Decrypter.
+func NewOaepDecrypter(
+	privateKey *rsa.PrivateKey,
+	plainTextCoder textcoder.Coder,
+	cipherCoder textcoder.Coder,
+	options ...func(*oaepParams),
+) oaepDecrypter {
+	return oaepDecrypter{
+		params:         newOaepParams(options...),
+		privateKey:     privateKey,
+		plainTextCoder: plainTextCoder,
+		cipherCoder:    cipherCoder,
+	}
+}
+
+// Decrypt cipher text and return plain text.
+func (d oaepDecrypter) Decrypt(cipherText string) (plainText string, err error) {
+	cipherTextBytes, err := d.cipherCoder.Decode(cipherText)
+	if err != nil {
+		err = fmt.Errorf("failed to decode cipher text: %w", err)
+		return
+	}
+
+	plainTextBytes, err := Decrypt(
+		d.params.hash,
+		d.params.mgfHash,
+		d.privateKey,
+		cipherTextBytes,
+		d.params.label,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt cipher text: %w", err)
+	}
+
+	plainText = d.plainTextCoder.Encode(plainTextBytes)
+
+	return
+}
@@ -0,0 +1,59 @@
+package rsa
+
+import (
+	"crypto/rsa"
+	"fmt"
+
+	textcoder "github.com/imylam/text-coder"
+)
+
+var _ Encrypter = oaepEncrypter{}
+
+type oaepEncrypter struct {
+	params         oaepParams
+	publicKey      *rsa.PublicKey
+	plainTextCoder textcoder.Coder
+	cipherCoder    textcoder.Coder
+}
+
+// NewOaepEncrypter creates encrypter which encrypt plain text
+// with RSA public key using RSA-OAEP, SHA256 by default.
+//
+// Implements Encrypter.
+func NewOaepEncrypter(
+	publicKey *rsa.PublicKey,
+	plainTextCoder textcoder.Coder,
+	cipherCoder textcoder.Coder,
+	options ...func(*oaepParams),
+) oaepEncrypter {
+	return oaepEncrypter{
+		params:         newOaepParams(options...),
+		publicKey:      publicKey,
+		plainTextCoder: plainTextCoder,
+		cipherCoder:    cipherCoder,
+	}
+}
+
+// Encrypt plain text and return cipher text.
+func (e oaepEncrypter) Encrypt(plainText string) (cipherText string, err error) {
+	plainTextBytes, err := e.plainTextCoder.Decode(plainText)
+	if err != nil {
+		err = fmt.Errorf("failed to decode plain text: %w", err)
+		return
+	}
+
+	cipherTextBytes, err := Encrypt(
+		e.params.hash,
+		e.params.mgfHash,
+		e.publicKey,
+		plainTextBytes,
+		e.params.label,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt plain text: %w", err)
+	}
+
+	cipherText = e.cipherCoder.Encode(cipherTextBytes)
+
+	return
+}
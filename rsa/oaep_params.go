@@ -0,0 +1,47 @@
+package rsa
+
+import (
+	"crypto"
+)
+
+type oaepParams struct {
+	hash    crypto.Hash
+	mgfHash crypto.Hash
+	label   []byte
+}
+
+// newOaepParams returns RSA-OAEP parameters defaulting to SHA-256
+// with an empty label. MGF1 uses the same hash unless set otherwise.
+func newOaepParams(options ...func(*oaepParams)) oaepParams {
+	p := oaepParams{
+		hash: crypto.SHA256,
+	}
+	for _, o := range options {
+		o(&p)
+	}
+	if p.mgfHash == 0 {
+		p.mgfHash = p.hash
+	}
+	return p
+}
+
+// WithOaepHash sets the hash used for the label digest.
+func WithOaepHash(hash crypto.Hash) func(*oaepParams) {
+	return func(p *oaepParams) {
+		p.hash = hash
+	}
+}
+
+// WithMgf1Hash sets the hash used by the MGF1 mask generation function.
+func WithMgf1Hash(mgfHash crypto.Hash) func(*oaepParams) {
+	return func(p *oaepParams) {
+		p.mgfHash = mgfHash
+	}
+}
+
+// WithLabel sets the label bound to the cipher text.
+func WithLabel(label []byte) func(*oaepParams) {
+	return func(p *oaepParams) {
+		p.label = label
+	}
+}
@@ -0,0 +1,197 @@
+package rsa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+
+	textcoder "github.com/imylam/text-coder"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	PlainText = "lorem ipsum"
+)
+
+var (
+	oaepPriKey, _ = pkcs1PriKeyParser.Parse(pkcs1PriKeyPem)
+	oaepPubKey, _ = pkcs1PubKeyParser.Parse(pkcs1PubKeyPem)
+)
+
+func TestOaepRoundTrip(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		options []func(*oaepParams)
+	}{
+		{
+			name:    "GIVEN_default_params_WHEN_decrypting_own_cipher_text_THEN_return_plain_text",
+			options: nil,
+		},
+		{
+			name:    "GIVEN_sha512_WHEN_decrypting_own_cipher_text_THEN_return_plain_text",
+			options: []func(*oaepParams){WithOaepHash(crypto.SHA512)},
+		},
+		{
+			name:    "GIVEN_sha256_and_mgf1_sha1_WHEN_decrypting_own_cipher_text_THEN_return_plain_text",
+			options: []func(*oaepParams){WithOaepHash(crypto.SHA256), WithMgf1Hash(crypto.SHA1)},
+		},
+		{
+			name:    "GIVEN_label_WHEN_decrypting_own_cipher_text_THEN_return_plain_text",
+			options: []func(*oaepParams){WithMgf1Hash(crypto.SHA384), WithLabel([]byte("label"))},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encrypter := NewOaepEncrypter(oaepPubKey, &textcoder.Utf8Coder{}, &textcoder.Base64StdCoder{}, tc.options...)
+			decrypter := NewOaepDecrypter(oaepPriKey, &textcoder.Utf8Coder{}, &textcoder.Base64StdCoder{}, tc.options...)
+
+			cipherText, err := encrypter.Encrypt(PlainText)
+			assert.NoError(t, err)
+
+			plainText, err := decrypter.Decrypt(cipherText)
+			assert.NoError(t, err)
+			assert.Equal(t, PlainText, plainText)
+		})
+	}
+}
+
+func TestOaepInteroperableWithCryptoRsa(t *testing.T) {
+	hash := crypto.SHA256
+	label := []byte("label")
+
+	t.Run("GIVEN_own_oaep_encoding_WHEN_decrypting_with_crypto_rsa_THEN_return_plain_text", func(t *testing.T) {
+		cipherTextBytes, err := encryptOAEP(hash, hash, oaepPubKey, []byte(PlainText), label)
+		assert.NoError(t, err)
+
+		plainTextBytes, err := rsa.DecryptOAEP(hash.New(), rand.Reader, oaepPriKey, cipherTextBytes, label)
+		assert.NoError(t, err)
+		assert.Equal(t, PlainText, string(plainTextBytes))
+	})
+
+	t.Run("GIVEN_own_oaep_encoding_with_mgf1_sha1_WHEN_decrypting_with_crypto_rsa_THEN_return_plain_text", func(t *testing.T) {
+		cipherTextBytes, err := encryptOAEP(hash, crypto.SHA1, oaepPubKey, []byte(PlainText), label)
+		assert.NoError(t, err)
+
+		plainTextBytes, err := oaepPriKey.Decrypt(
+			rand.Reader,
+			cipherTextBytes,
+			&rsa.OAEPOptions{Hash: hash, MGFHash: crypto.SHA1, Label: label},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, PlainText, string(plainTextBytes))
+	})
+}
+
+func TestOaepDecryptsOpenSSLCipherText(t *testing.T) {
+	// openssl pkeyutl -encrypt -inkey pkcs1.pem -pkeyopt rsa_padding_mode:oaep \
+	//   -pkeyopt rsa_oaep_md:sha256 -pkeyopt rsa_mgf1_md:sha1 \
+	//   -pkeyopt rsa_oaep_label:6c6162656c
+	cipherText := "b2Lsd2Kt5gLVAvGU1Qc6KLj+9qQmUUMwrGnXzKFU6EcKX/V658Sini5iyAfwfukNAdcpunpliWgIzIcUXxvK6+6gfU4YcYEbhNNp0KPjc399KEbABLOdqAANyDv8UPjx6qnh2kUapviGr2WG6zt/2SuE5jswFu4fwm7SOZxCxD4qA+MrkDYdas3FX4HqATrqxhhAmKGkIVb2nudvVVtZjmPLDLFW8gjYviFt3GHo86XGWFMzXneN+n1P3gjyuqEBhzI9hez35CnTQLxD4lM2Sx4LCIqgUhNFlIHJ13Tmk2iP4+1XKyJvuB4uBZ/p+N+WkkYD12QXXRxAVlfh/K7hyA=="
+
+	decrypter := NewOaepDecrypter(
+		oaepPriKey,
+		&textcoder.Utf8Coder{},
+		&textcoder.Base64StdCoder{},
+		WithOaepHash(crypto.SHA256),
+		WithMgf1Hash(crypto.SHA1),
+		WithLabel([]byte("label")),
+	)
+
+	plainText, err := decrypter.Decrypt(cipherText)
+
+	assert.NoError(t, err)
+	assert.Equal(t, PlainText, plainText)
+}
+
+func TestOaepMismatchedParamsShouldThrowError(t *testing.T) {
+
+	testCases := []struct {
+		name             string
+		encrypterOptions []func(*oaepParams)
+		decrypterOptions []func(*oaepParams)
+	}{
+		{
+			name:             "GIVEN_different_label_WHEN_decrypting_THEN_return_err",
+			encrypterOptions: []func(*oaepParams){WithLabel([]byte("label"))},
+			decrypterOptions: []func(*oaepParams){WithLabel([]byte("another label"))},
+		},
+		{
+			name:             "GIVEN_different_hash_WHEN_decrypting_THEN_return_err",
+			encrypterOptions: []func(*oaepParams){WithOaepHash(crypto.SHA256)},
+			decrypterOptions: []func(*oaepParams){WithOaepHash(crypto.SHA512)},
+		},
+		{
+			name:             "GIVEN_different_mgf1_hash_WHEN_decrypting_THEN_return_err",
+			encrypterOptions: []func(*oaepParams){WithMgf1Hash(crypto.SHA1)},
+			decrypterOptions: []func(*oaepParams){WithMgf1Hash(crypto.SHA512)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encrypter := NewOaepEncrypter(oaepPubKey, &textcoder.Utf8Coder{}, &textcoder.HexCoder{}, tc.encrypterOptions...)
+			decrypter := NewOaepDecrypter(oaepPriKey, &textcoder.Utf8Coder{}, &textcoder.HexCoder{}, tc.decrypterOptions...)
+
+			cipherText, err := encrypter.Encrypt(PlainText)
+			assert.NoError(t, err)
+
+			plainText, err := decrypter.Decrypt(cipherText)
+
+			expectedErrMsg := "failed to decrypt cipher text:"
+			assert.Empty(t, plainText)
+			assert.ErrorContainsf(
+				t,
+				err,
+				expectedErrMsg,
+				"expected error containing %q, got %s", expectedErrMsg, err,
+			)
+		})
+	}
+}
+
+func TestOaepMessageTooLongShouldThrowError(t *testing.T) {
+	encrypter := NewOaepEncrypter(oaepPubKey, &textcoder.Utf8Coder{}, &textcoder.HexCoder{}, WithMgf1Hash(crypto.SHA1))
+
+	cipherText, err := encrypter.Encrypt(strings.Repeat("a", oaepPubKey.Size()))
+
+	assert.Empty(t, cipherText)
+	assert.ErrorIs(t, err, rsa.ErrMessageTooLong)
+}
+
+func TestWrongPlainTextOrCipherTextCodingShouldThrowError(t *testing.T) {
+
+	utf8Text := "abc"
+	errDecodePlainText := "failed to decode plain text:"
+	errDecodeCipherText := "failed to decode cipher text:"
+
+	hexEncrypter := NewOaepEncrypter(oaepPubKey, &textcoder.HexCoder{}, &textcoder.HexCoder{})
+	hexDecrypter := NewOaepDecrypter(oaepPriKey, &textcoder.HexCoder{}, &textcoder.HexCoder{})
+
+	t.Run("GIVEN_wrong_plain_text_coding_WHEN_encrypting_THEN_return_err", func(t *testing.T) {
+		cipherText, err := hexEncrypter.Encrypt(utf8Text)
+
+		assert.Empty(t, cipherText)
+		assert.ErrorContainsf(
+			t,
+			err,
+			errDecodePlainText,
+			"expected error containing %q, got %s", errDecodePlainText, err,
+		)
+	})
+
+	t.Run("GIVEN_wrong_cipher_text_coding_WHEN_decrypting_THEN_return_err", func(t *testing.T) {
+		plainText, err := hexDecrypter.Decrypt(utf8Text)
+
+		assert.Empty(t, plainText)
+		assert.ErrorContainsf(
+			t,
+			err,
+			errDecodeCipherText,
+			"expected error containing %q, got %s", errDecodeCipherText, err,
+		)
+	})
+}
@@ -0,0 +1,466 @@
+package rsa
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	KDF_PBKDF2 = "PBKDF2"
+	KDF_SCRYPT = "scrypt"
+
+	CIPHER_AES_128_CBC = "AES-128-CBC"
+	CIPHER_AES_192_CBC = "AES-192-CBC"
+	CIPHER_AES_256_CBC = "AES-256-CBC"
+	CIPHER_AES_128_GCM = "AES-128-GCM"
+	CIPHER_AES_256_GCM = "AES-256-GCM"
+
+	pbes2SaltSize = 16
+	gcmTagSize    = 16
+
+	// Upper bounds on KDF parameters read from encrypted keys, so that a
+	// hostile key cannot make Parse spend unbounded time or memory. Marshal
+	// keeps within them so that every key marshalled can be parsed.
+	maxPbkdf2Iterations      = 10_000_000
+	maxScryptMemory          = 1 << 30
+	maxScryptParallelization = 16
+)
+
+var (
+	oidPbes2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPbkdf2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidScrypt = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11591, 4, 11}
+
+	oidHmacWithSha1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHmacWithSha256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHmacWithSha384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHmacWithSha512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	pbkdf2Prfs = []struct {
+		oid  asn1.ObjectIdentifier
+		hash crypto.Hash
+	}{
+		{oidHmacWithSha1, crypto.SHA1},
+		{oidHmacWithSha256, crypto.SHA256},
+		{oidHmacWithSha384, crypto.SHA384},
+		{oidHmacWithSha512, crypto.SHA512},
+	}
+
+	pbes2Ciphers = []pbes2Cipher{
+		{CIPHER_AES_128_CBC, asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}, 16, false},
+		{CIPHER_AES_192_CBC, asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}, 24, false},
+		{CIPHER_AES_256_CBC, asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}, 32, false},
+		{CIPHER_AES_128_GCM, asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 6}, 16, true},
+		{CIPHER_AES_256_GCM, asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 46}, 32, true},
+	}
+)
+
+// encryptedPrivateKeyInfo as in RFC 5208, section 6.
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+// pbes2Params as in RFC 8018, appendix A.4.
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params as in RFC 8018, appendix A.2.
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	Prf            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// scryptParams as in RFC 7914, section 7.1.
+type scryptParams struct {
+	Salt                     []byte
+	CostParameter            int
+	BlockSize                int
+	ParallelizationParameter int
+	KeyLength                int `asn1:"optional"`
+}
+
+// gcmParams as in RFC 5084, section 3.2.
+type gcmParams struct {
+	Nonce  []byte
+	ICVLen int `asn1:"default:12,optional"`
+}
+
+type pbes2Cipher struct {
+	name    string
+	oid     asn1.ObjectIdentifier
+	keySize int
+	gcm     bool
+}
+
+// pbes2Encrypt encrypts data with PBES2 and returns the DER encoded
+// EncryptedPrivateKeyInfo.
+func pbes2Encrypt(p *pkcs8EncryptedPrivateKeyParser, data []byte) ([]byte, error) {
+	c, err := pbes2CipherByName(p.cipher)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, pbes2SaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	var kdf pkix.AlgorithmIdentifier
+	var key []byte
+
+	switch p.kdf {
+	case KDF_PBKDF2:
+		if err := checkPbkdf2Iterations(p.pbkdf2Iterations); err != nil {
+			return nil, err
+		}
+
+		prfOid, err := pbkdf2PrfOid(p.pbkdf2Hash)
+		if err != nil {
+			return nil, err
+		}
+
+		kdf, err = algorithmIdentifier(oidPbkdf2, pbkdf2Params{
+			Salt:           salt,
+			IterationCount: p.pbkdf2Iterations,
+			Prf:            pkix.AlgorithmIdentifier{Algorithm: prfOid, Parameters: asn1.NullRawValue},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		key = pbkdf2.Key(p.passphrase, salt, p.pbkdf2Iterations, c.keySize, p.pbkdf2Hash.New)
+	case KDF_SCRYPT:
+		if err := checkScryptParams(p.scryptN, p.scryptR, p.scryptP); err != nil {
+			return nil, err
+		}
+
+		kdf, err = algorithmIdentifier(oidScrypt, scryptParams{
+			Salt:                     salt,
+			CostParameter:            p.scryptN,
+			BlockSize:                p.scryptR,
+			ParallelizationParameter: p.scryptP,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		key, err = scrypt.Key(p.passphrase, salt, p.scryptN, p.scryptR, p.scryptP, c.keySize)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key derivation function %q", p.kdf)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var scheme pkix.AlgorithmIdentifier
+	var encrypted []byte
+
+	if c.gcm {
+		aead, err := cipher.NewGCMWithTagSize(block, gcmTagSize)
+		if err != nil {
+			return nil, err
+		}
+
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return nil, err
+		}
+
+		scheme, err = algorithmIdentifier(c.oid, gcmParams{Nonce: nonce, ICVLen: gcmTagSize})
+		if err != nil {
+			return nil, err
+		}
+
+		encrypted = aead.Seal(nil, nonce, data, nil)
+	} else {
+		iv := make([]byte, aes.BlockSize)
+		if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+			return nil, err
+		}
+
+		scheme, err = algorithmIdentifier(c.oid, iv)
+		if err != nil {
+			return nil, err
+		}
+
+		encrypted = pkcs7Pad(data, aes.BlockSize)
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, encrypted)
+	}
+
+	encryptionAlgorithm, err := algorithmIdentifier(oidPbes2, pbes2Params{
+		KeyDerivationFunc: kdf,
+		EncryptionScheme:  scheme,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		EncryptionAlgorithm: encryptionAlgorithm,
+		EncryptedData:       encrypted,
+	})
+}
+
+// pbes2Decrypt decrypts the DER encoded EncryptedPrivateKeyInfo der.
+func pbes2Decrypt(passphrase, der []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if err := unmarshalStrict(der, &info); err != nil {
+		return nil, fmt.Errorf("invalid encrypted private key info: %w", err)
+	}
+
+	if !info.EncryptionAlgorithm.Algorithm.Equal(oidPbes2) {
+		return nil, fmt.Errorf("unsupported encryption algorithm %s", info.EncryptionAlgorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if err := unmarshalStrict(info.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("invalid PBES2 parameters: %w", err)
+	}
+
+	c, err := pbes2CipherByOid(params.EncryptionScheme.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := deriveKey(passphrase, params.KeyDerivationFunc, c.keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.gcm {
+		var gcm gcmParams
+		if err := unmarshalStrict(params.EncryptionScheme.Parameters.FullBytes, &gcm); err != nil {
+			return nil, fmt.Errorf("invalid GCM parameters: %w", err)
+		}
+
+		if gcm.ICVLen < 12 || gcm.ICVLen > 16 {
+			return nil, fmt.Errorf("unsupported GCM tag size %d", gcm.ICVLen)
+		}
+
+		aead, err := cipher.NewGCMWithTagSize(block, gcm.ICVLen)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(gcm.Nonce) != aead.NonceSize() {
+			return nil, errors.New("invalid GCM nonce size")
+		}
+
+		data, err := aead.Open(nil, gcm.Nonce, info.EncryptedData, nil)
+		if err != nil {
+			return nil, errors.New(ERR_INCORRECT_PASSPHRASE)
+		}
+
+		return data, nil
+	}
+
+	var iv []byte
+	if err := unmarshalStrict(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("invalid CBC parameters: %w", err)
+	}
+
+	if len(iv) != aes.BlockSize {
+		return nil, errors.New("invalid CBC iv size")
+	}
+
+	if len(info.EncryptedData) == 0 || len(info.EncryptedData)%aes.BlockSize != 0 {
+		return nil, errors.New("invalid encrypted data size")
+	}
+
+	data := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(data, info.EncryptedData)
+
+	data, err = pkcs7Unpad(data, aes.BlockSize)
+	if err != nil {
+		return nil, errors.New(ERR_INCORRECT_PASSPHRASE)
+	}
+
+	return data, nil
+}
+
+func deriveKey(passphrase []byte, kdf pkix.AlgorithmIdentifier, keySize int) ([]byte, error) {
+	switch {
+	case kdf.Algorithm.Equal(oidPbkdf2):
+		var params pbkdf2Params
+		if err := unmarshalStrict(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, fmt.Errorf("invalid PBKDF2 parameters: %w", err)
+		}
+
+		if err := checkPbkdf2Iterations(params.IterationCount); err != nil {
+			return nil, err
+		}
+
+		if params.KeyLength != 0 && params.KeyLength != keySize {
+			return nil, fmt.Errorf("unsupported PBKDF2 key length %d", params.KeyLength)
+		}
+
+		hash := crypto.SHA1
+		if len(params.Prf.Algorithm) > 0 {
+			var err error
+			hash, err = pbkdf2PrfHash(params.Prf.Algorithm)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return pbkdf2.Key(passphrase, params.Salt, params.IterationCount, keySize, hash.New), nil
+	case kdf.Algorithm.Equal(oidScrypt):
+		var params scryptParams
+		if err := unmarshalStrict(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, fmt.Errorf("invalid scrypt parameters: %w", err)
+		}
+
+		if params.KeyLength != 0 && params.KeyLength != keySize {
+			return nil, fmt.Errorf("unsupported scrypt key length %d", params.KeyLength)
+		}
+
+		err := checkScryptParams(params.CostParameter, params.BlockSize, params.ParallelizationParameter)
+		if err != nil {
+			return nil, err
+		}
+
+		return scrypt.Key(
+			passphrase,
+			params.Salt,
+			params.CostParameter,
+			params.BlockSize,
+			params.ParallelizationParameter,
+			keySize,
+		)
+	default:
+		return nil, fmt.Errorf("unsupported key derivation function %s", kdf.Algorithm)
+	}
+}
+
+func pbes2CipherByName(name string) (pbes2Cipher, error) {
+	for _, c := range pbes2Ciphers {
+		if c.name == name {
+			return c, nil
+		}
+	}
+	return pbes2Cipher{}, fmt.Errorf("unsupported cipher %q", name)
+}
+
+func pbes2CipherByOid(oid asn1.ObjectIdentifier) (pbes2Cipher, error) {
+	for _, c := range pbes2Ciphers {
+		if c.oid.Equal(oid) {
+			return c, nil
+		}
+	}
+	return pbes2Cipher{}, fmt.Errorf("unsupported cipher %s", oid)
+}
+
+func pbkdf2PrfOid(hash crypto.Hash) (asn1.ObjectIdentifier, error) {
+	for _, prf := range pbkdf2Prfs {
+		if prf.hash == hash {
+			return prf.oid, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported PBKDF2 hash %s", hash)
+}
+
+func pbkdf2PrfHash(oid asn1.ObjectIdentifier) (crypto.Hash, error) {
+	for _, prf := range pbkdf2Prfs {
+		if prf.oid.Equal(oid) {
+			return prf.hash, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported PBKDF2 pseudorandom function %s", oid)
+}
+
+func algorithmIdentifier(oid asn1.ObjectIdentifier, params interface{}) (pkix.AlgorithmIdentifier, error) {
+	paramsBytes, err := asn1.Marshal(params)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+
+	return pkix.AlgorithmIdentifier{
+		Algorithm:  oid,
+		Parameters: asn1.RawValue{FullBytes: paramsBytes},
+	}, nil
+}
+
+// unmarshalStrict unmarshals der into v, rejecting trailing data.
+func unmarshalStrict(der []byte, v interface{}) error {
+	rest, err := asn1.Unmarshal(der, v)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return errors.New("trailing data")
+	}
+	return nil
+}
+
+func pkcs7Pad(data []byte, blockSize int) []byte {
+	padding := blockSize - len(data)%blockSize
+	padded := make([]byte, len(data)+padding)
+	copy(padded, data)
+	for i := len(data); i < len(padded); i++ {
+		padded[i] = byte(padding)
+	}
+	return padded
+}
+
+func pkcs7Unpad(data []byte, blockSize int) ([]byte, error) {
+	padding := int(data[len(data)-1])
+	if padding < 1 || padding > blockSize || padding > len(data) {
+		return nil, errors.New("invalid padding")
+	}
+
+	good := 1
+	for _, b := range data[len(data)-padding:] {
+		good &= subtle.ConstantTimeByteEq(b, byte(padding))
+	}
+	if good != 1 {
+		return nil, errors.New("invalid padding")
+	}
+
+	return data[:len(data)-padding], nil
+}
+
+// checkPbkdf2Iterations bounds the number of iterations of PBKDF2.
+func checkPbkdf2Iterations(iterations int) error {
+	if iterations < 1 || iterations > maxPbkdf2Iterations {
+		return fmt.Errorf("unsupported PBKDF2 iteration count %d", iterations)
+	}
+
+	return nil
+}
+
+// checkScryptParams bounds the memory and the number of sequential passes
+// of scrypt.
+func checkScryptParams(n, r, p int) error {
+	if r < 1 || p < 1 || p > maxScryptParallelization || n < 2 ||
+		int64(n)*int64(r)*128 > maxScryptMemory {
+		return errors.New("unsupported scrypt parameters")
+	}
+
+	return nil
+}
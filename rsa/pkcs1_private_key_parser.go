@@ -8,14 +8,16 @@ import (
 	"fmt"
 )
 
-type Pkcs1PrivateKeyParser struct{}
+type Pkcs1PrivateKeyParser struct {
+	Strict bool // reject mismatched PEM block types and trailing data.
+}
 
 // Marsal *rsa.PrivateKey to PKCS #1, ASN.1 DER form.
 func (p *Pkcs1PrivateKeyParser) Marshal(privateKey *rsa.PrivateKey) (string, error) {
 	privKeyBytes := x509.MarshalPKCS1PrivateKey(privateKey)
 	privkeyPem := pem.EncodeToMemory(
 		&pem.Block{
-			Type:  "RSA PRIVATE KEY",
+			Type:  PEM_TYPE_PKCS1_PRIVATE_KEY,
 			Bytes: privKeyBytes,
 		},
 	)
@@ -24,11 +26,17 @@ func (p *Pkcs1PrivateKeyParser) Marshal(privateKey *rsa.PrivateKey) (string, err
 
 // Parse an RSA private key pem in PKCS #1, ASN.1 DER form.
 func (p *Pkcs1PrivateKeyParser) Parse(privatePem string) (*rsa.PrivateKey, error) {
-	block, _ := pem.Decode([]byte(privatePem))
+	block, rest := pem.Decode([]byte(privatePem))
 	if block == nil {
 		return nil, errors.New("failed to decode PKCS #1 private key pem") //
 	}
 
+	if p.Strict {
+		if err := checkPemBlock(block, rest, PEM_TYPE_PKCS1_PRIVATE_KEY); err != nil {
+			return nil, fmt.Errorf("failed to parse PKCS #1 private key pem: %w", err)
+		}
+	}
+
 	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse PKCS #1 private key pem: %w", err)
@@ -8,14 +8,16 @@ import (
 	"fmt"
 )
 
-type Pkcs1PublicKeyParser struct{}
+type Pkcs1PublicKeyParser struct {
+	Strict bool // reject mismatched PEM block types and trailing data.
+}
 
 // Marshal *rsa.PublicKey to an RSA public key to PKCS #1, ASN.1 DER form.
 func (p *Pkcs1PublicKeyParser) Marshal(publicKey *rsa.PublicKey) (string, error) {
 	publicKeyBytes := x509.MarshalPKCS1PublicKey(publicKey)
 	publicKeyPem := pem.EncodeToMemory(
 		&pem.Block{
-			Type:  "RSA PUBLIC KEY",
+			Type:  PEM_TYPE_PKCS1_PUBLIC_KEY,
 			Bytes: publicKeyBytes,
 		},
 	)
@@ -24,11 +26,17 @@ func (p *Pkcs1PublicKeyParser) Marshal(publicKey *rsa.PublicKey) (string, error)
 
 // Parse an RSA public key pem in PKCS #1, ASN.1 DER form.
 func (p *Pkcs1PublicKeyParser) Parse(publicKeyPem string) (*rsa.PublicKey, error) {
-	block, _ := pem.Decode([]byte(publicKeyPem))
+	block, rest := pem.Decode([]byte(publicKeyPem))
 	if block == nil {
 		return nil, errors.New("failed to decode Pkcs1 public key pem")
 	}
 
+	if p.Strict {
+		if err := checkPemBlock(block, rest, PEM_TYPE_PKCS1_PUBLIC_KEY); err != nil {
+			return nil, fmt.Errorf("failed to parse PKCS #1 public key pem: %w", err)
+		}
+	}
+
 	publicKey, err := x509.ParsePKCS1PublicKey(block.Bytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse PKCS #1 publi key pem: %w", err)
@@ -0,0 +1,146 @@
+package rsa
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+const (
+	ERR_INCORRECT_PASSPHRASE = "incorrect passphrase"
+
+	DefaultPbkdf2Iterations = 600_000
+)
+
+type pkcs8EncryptedPrivateKeyParser struct {
+	passphrase       []byte
+	kdf              string
+	pbkdf2Iterations int
+	pbkdf2Hash       crypto.Hash
+	scryptN          int
+	scryptR          int
+	scryptP          int
+	cipher           string
+	strict           bool
+}
+
+// NewPkcs8EncryptedPrivateKeyParser creates private key parser which
+// marshal and parse password-protected PKCS #8 private keys using PBES2.
+//
+// By default, keys are marshaled with PBKDF2-HMAC-SHA256 and AES-256-CBC.
+// Parse accepts PBKDF2 or scrypt with AES-CBC or AES-GCM.
+//
+// Implements PrivateKeyParser.
+func NewPkcs8EncryptedPrivateKeyParser(
+	passphrase []byte,
+	options ...func(*pkcs8EncryptedPrivateKeyParser),
+) *pkcs8EncryptedPrivateKeyParser {
+	p := &pkcs8EncryptedPrivateKeyParser{
+		passphrase:       passphrase,
+		kdf:              KDF_PBKDF2,
+		pbkdf2Iterations: DefaultPbkdf2Iterations,
+		pbkdf2Hash:       crypto.SHA256,
+		cipher:           CIPHER_AES_256_CBC,
+	}
+	for _, o := range options {
+		o(p)
+	}
+	return p
+}
+
+// WithPbkdf2 marshals keys with PBKDF2 using HMAC with the crypto hash
+// given as pseudorandom function.
+func WithPbkdf2(
+	iterations int,
+	hash crypto.Hash,
+) func(*pkcs8EncryptedPrivateKeyParser) {
+	return func(p *pkcs8EncryptedPrivateKeyParser) {
+		p.kdf = KDF_PBKDF2
+		p.pbkdf2Iterations = iterations
+		p.pbkdf2Hash = hash
+	}
+}
+
+// WithScrypt marshals keys with scrypt.
+func WithScrypt(
+	n, r, p int,
+) func(*pkcs8EncryptedPrivateKeyParser) {
+	return func(parser *pkcs8EncryptedPrivateKeyParser) {
+		parser.kdf = KDF_SCRYPT
+		parser.scryptN = n
+		parser.scryptR = r
+		parser.scryptP = p
+	}
+}
+
+// WithCipher marshals keys with the cipher given, one of CIPHER_AES_*.
+func WithCipher(
+	cipher string,
+) func(*pkcs8EncryptedPrivateKeyParser) {
+	return func(p *pkcs8EncryptedPrivateKeyParser) {
+		p.cipher = cipher
+	}
+}
+
+// WithStrictPem rejects mismatched PEM block types and trailing data
+// when parsing.
+func WithStrictPem() func(*pkcs8EncryptedPrivateKeyParser) {
+	return func(p *pkcs8EncryptedPrivateKeyParser) {
+		p.strict = true
+	}
+}
+
+// Marshal *rsa.PrivateKey to encrypted PKCS #8, ASN.1 DER form.
+func (p *pkcs8EncryptedPrivateKeyParser) Marshal(privateKey *rsa.PrivateKey) (string, error) {
+	privKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal private key to PKCS #8 form: %w", err)
+	}
+
+	encryptedBytes, err := pbes2Encrypt(p, privKeyBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt PKCS #8 private key: %w", err)
+	}
+
+	privkeyPem := pem.EncodeToMemory(
+		&pem.Block{
+			Type:  PEM_TYPE_ENCRYPTED_PRIVATE_KEY,
+			Bytes: encryptedBytes,
+		},
+	)
+	return string(privkeyPem), nil
+}
+
+// Parse an RSA private key pem in encrypted PKCS #8, ASN.1 DER form.
+func (p *pkcs8EncryptedPrivateKeyParser) Parse(privatePem string) (*rsa.PrivateKey, error) {
+	block, rest := pem.Decode([]byte(privatePem))
+	if block == nil {
+		return nil, errors.New("failed to decode encrypted PKCS #8 private key pem")
+	}
+
+	if p.strict {
+		if err := checkPemBlock(block, rest, PEM_TYPE_ENCRYPTED_PRIVATE_KEY); err != nil {
+			return nil, fmt.Errorf("failed to parse encrypted PKCS #8 private key pem: %w", err)
+		}
+	}
+
+	privKeyBytes, err := pbes2Decrypt(p.passphrase, block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted PKCS #8 private key pem: %w", err)
+	}
+
+	result, err := x509.ParsePKCS8PrivateKey(privKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted PKCS #8 private key pem: %w", err)
+	}
+
+	privateKey, ok := result.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse encrypted PKCS #8 private key pem: %w", ErrNotRSAKey)
+	}
+
+	return privateKey, nil
+}
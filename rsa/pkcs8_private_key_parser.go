@@ -8,7 +8,9 @@ import (
 	"fmt"
 )
 
-type Pkcs8PrivateKeyParser struct{}
+type Pkcs8PrivateKeyParser struct {
+	Strict bool // reject mismatched PEM block types and trailing data.
+}
 
 // Marsal *rsa.PrivateKey to PKCS #8, ASN.1 DER form.
 func (p *Pkcs8PrivateKeyParser) Marshal(privateKey *rsa.PrivateKey) (string, error) {
@@ -19,7 +21,7 @@ func (p *Pkcs8PrivateKeyParser) Marshal(privateKey *rsa.PrivateKey) (string, err
 
 	privkeyPem := pem.EncodeToMemory(
 		&pem.Block{
-			Type:  "RSA PRIVATE KEY",
+			Type:  PEM_TYPE_PKCS8_PRIVATE_KEY,
 			Bytes: privKeyBytes,
 		},
 	)
@@ -28,20 +30,26 @@ func (p *Pkcs8PrivateKeyParser) Marshal(privateKey *rsa.PrivateKey) (string, err
 
 // Parse an RSA private key pem in PKCS #8, ASN.1 DER form.
 func (p *Pkcs8PrivateKeyParser) Parse(privatePem string) (*rsa.PrivateKey, error) {
-	block, _ := pem.Decode([]byte(privatePem))
+	block, rest := pem.Decode([]byte(privatePem))
 	if block == nil {
 		return nil, errors.New("failed to decode PKCS #8 private key pem") //
 	}
 
+	if p.Strict {
+		if err := checkPemBlock(block, rest, PEM_TYPE_PKCS8_PRIVATE_KEY); err != nil {
+			return nil, fmt.Errorf("failed to parse PKCS #8 private key pem: %w", err)
+		}
+	}
+
 	result, err := x509.ParsePKCS8PrivateKey(block.Bytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse to PKCS #8 private key pem: %w", err)
+		return nil, fmt.Errorf("failed to parse PKCS #8 private key pem: %w", err)
 	}
 
 	privateKey, ok := result.(*rsa.PrivateKey)
 	if !ok {
-		err = fmt.Errorf("failed to parse to PKCS #8 private key pem: %w", err)
+		return nil, fmt.Errorf("failed to parse PKCS #8 private key pem: %w", ErrNotRSAKey)
 	}
 
-	return privateKey, err
+	return privateKey, nil
 }
@@ -8,18 +8,20 @@ import (
 	"fmt"
 )
 
-type PkixPublicKeyParser struct{}
+type PkixPublicKeyParser struct {
+	Strict bool // reject mismatched PEM block types and trailing data.
+}
 
 // Marshal *rsa.PublicKey to an RSA public key to PKIX, ASN.1 DER form.
 func (p *PkixPublicKeyParser) Marshal(publicKey *rsa.PublicKey) (string, error) {
 	publicKeyBytes, err := x509.MarshalPKIXPublicKey(publicKey)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal private key to PKCS #8 form: %w", err)
+		return "", fmt.Errorf("failed to marshal public key to PKIX form: %w", err)
 	}
 
 	publicKeyPem := pem.EncodeToMemory(
 		&pem.Block{
-			Type:  "RSA PUBLIC KEY",
+			Type:  PEM_TYPE_PKIX_PUBLIC_KEY,
 			Bytes: publicKeyBytes,
 		},
 	)
@@ -28,11 +30,17 @@ func (p *PkixPublicKeyParser) Marshal(publicKey *rsa.PublicKey) (string, error)
 
 // Parse an RSA public key pem in PKIX, ASN.1 DER form.
 func (p *PkixPublicKeyParser) Parse(publicKeyPem string) (*rsa.PublicKey, error) {
-	block, _ := pem.Decode([]byte(publicKeyPem))
+	block, rest := pem.Decode([]byte(publicKeyPem))
 	if block == nil {
 		return nil, errors.New("failed to decode PKIX public key pem")
 	}
 
+	if p.Strict {
+		if err := checkPemBlock(block, rest, PEM_TYPE_PKIX_PUBLIC_KEY); err != nil {
+			return nil, fmt.Errorf("failed to parse PKIX public key pem: %w", err)
+		}
+	}
+
 	result, err := x509.ParsePKIXPublicKey(block.Bytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse PKIX public key pem: %w", err)
@@ -40,7 +48,7 @@ func (p *PkixPublicKeyParser) Parse(publicKeyPem string) (*rsa.PublicKey, error)
 
 	publicKey, ok := result.(*rsa.PublicKey)
 	if !ok {
-		return nil, errors.New("pem given is not an rsa public key")
+		return nil, fmt.Errorf("failed to parse PKIX public key pem: %w", ErrNotRSAKey)
 	}
 
 	return publicKey, nil
@@ -2,56 +2,52 @@ package rsa
 
 import (
 	"crypto"
+	"crypto/rand"
 	"crypto/rsa"
+	"errors"
 )
 
-// // Encrypt plainText with public key using RSA-OAEP
-// func Encrypt(
-// 	hash crypto.Hash,
-// 	publicKey *rsa.PublicKey,
-// 	plainText string,
-// 	keyParser PublicKeyParser,
-// 	plainTextCoder, cipherTextCoder textcoder.Coder,
-// ) (cipherText string, err error) {
-// 	plainTextByte, err := plainTextCoder.Decode(plainText)
-// 	if err != nil {
-// 		err = fmt.Errorf("failed to decode plain text: %w", err)
-// 		return
-// 	}
+const (
+	ERR_HASH_UNAVAILABLE = "hash function is not available"
+)
 
-// 	rng := rand.Reader
-// 	cipherTextBytes, err := rsa.EncryptOAEP(hash.New(), rng, publicKey, plainTextByte, nil)
-// 	if err != nil {
-// 		return
-// 	}
+// Encrypt plainText with public key using RSA-OAEP, the crypto hash
+// and the MGF1 hash given
+func Encrypt(
+	hash crypto.Hash,
+	mgfHash crypto.Hash,
+	publicKey *rsa.PublicKey,
+	plainTextBytes, label []byte,
+) (cipherTextBytes []byte, err error) {
+	if !hash.Available() || !mgfHash.Available() {
+		return nil, errors.New(ERR_HASH_UNAVAILABLE)
+	}
 
-// 	cipherText = cipherTextCoder.Encode(cipherTextBytes)
-// 	return
-// }
+	if hash == mgfHash {
+		return rsa.EncryptOAEP(hash.New(), rand.Reader, publicKey, plainTextBytes, label)
+	}
 
-// // Decrypt cipherText with private key using RSA-OAEP
-// func Decrypt(
-// 	hash crypto.Hash,
-// 	privateKey *rsa.PrivateKey,
-// 	cipherText string,
-// 	keyParser PrivateKeyParser,
-// 	cipherTextCoder, plainTextCoder textcoder.Coder,
-// ) (plainText string, err error) {
-// 	cipherTextBytes, err := cipherTextCoder.Decode(cipherText)
-// 	if err != nil {
-// 		err = fmt.Errorf("failed to decode cipher text: %w", err)
-// 		return
-// 	}
+	return encryptOAEP(hash, mgfHash, publicKey, plainTextBytes, label)
+}
 
-// 	rng := rand.Reader
-// 	plainTextBytes, err := rsa.DecryptOAEP(hash.New(), rng, privateKey, cipherTextBytes, nil)
-// 	if err != nil {
-// 		return
-// 	}
+// Decrypt cipherText with private key using RSA-OAEP, the crypto hash
+// and the MGF1 hash given
+func Decrypt(
+	hash crypto.Hash,
+	mgfHash crypto.Hash,
+	privateKey *rsa.PrivateKey,
+	cipherTextBytes, label []byte,
+) (plainTextBytes []byte, err error) {
+	if !hash.Available() || !mgfHash.Available() {
+		return nil, errors.New(ERR_HASH_UNAVAILABLE)
+	}
 
-// 	plainText = plainTextCoder.Encode(plainTextBytes)
-// 	return
-// }
+	return privateKey.Decrypt(
+		rand.Reader,
+		cipherTextBytes,
+		&rsa.OAEPOptions{Hash: hash, MGFHash: mgfHash, Label: label},
+	)
+}
 
 // Sign a message with PrivateKey using RSA-PSS and the crypto hash given
 func Sign(
@@ -0,0 +1,159 @@
+package bcrypt
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/imylam/crypto-utils/signature"
+	textcoder "github.com/imylam/text-coder"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	ALGO                    = "bcrypt"
+	ERR_MISMATCHED_PASSWORD = "hashed password does not match the hash of password provided"
+
+	MinCost           = bcrypt.MinCost
+	MaxCost           = bcrypt.MaxCost
+	DefaultCost       = bcrypt.DefaultCost
+	MaxPasswordLength = 72 // the maximum password length in bytes.
+)
+
+var (
+	// ErrPasswordTooLong is returned for passwords longer than
+	// MaxPasswordLength, which bcrypt would silently truncate.
+	ErrPasswordTooLong = bcrypt.ErrPasswordTooLong
+)
+
+var _ signature.Signer = (*Bcrypt)(nil)
+var _ signature.Verifier = (*Bcrypt)(nil)
+var _ signature.PasswordHasher = (*Bcrypt)(nil)
+var _ signature.ContextSigner = (*Bcrypt)(nil)
+var _ signature.ContextVerifier = (*Bcrypt)(nil)
+
+type Bcrypt struct {
+	cost    int
+	pwCoder textcoder.Coder
+	preHash bool
+}
+
+// NewBcrypt creates password hasher which hash passwords with bcrypt at
+// the cost given, between MinCost and MaxCost.
+//
+// Passwords longer than MaxPasswordLength are rejected with
+// ErrPasswordTooLong unless WithPreHash is given.
+func NewBcrypt(
+	cost int,
+	pwCoder textcoder.Coder,
+	options ...func(*Bcrypt),
+) *Bcrypt {
+	b := &Bcrypt{
+		cost:    cost,
+		pwCoder: pwCoder,
+	}
+	for _, o := range options {
+		o(b)
+	}
+	return b
+}
+
+// WithPreHash hashes every password with SHA-256 and base64 before bcrypt,
+// so passwords of any length are accepted.
+//
+// Hashes produced with and without pre-hashing are indistinguishable, the
+// same mode must be used to verify them.
+func WithPreHash() func(*Bcrypt) {
+	return func(b *Bcrypt) {
+		b.preHash = true
+	}
+}
+
+// Algo returns the algorithm used for signing/verifying.
+func (b *Bcrypt) Algo() (algo string) {
+	return ALGO
+}
+
+// Sign implements signature.Signer.
+func (b *Bcrypt) Sign(
+	pw string,
+) (pwHash string, err error) {
+	if b.cost < MinCost || b.cost > MaxCost {
+		err = fmt.Errorf("invalid cost %d, must be between %d and %d", b.cost, MinCost, MaxCost)
+		return
+	}
+
+	pwBytes, err := b.decodePassword(pw)
+	if err != nil {
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword(pwBytes, b.cost)
+	if err != nil {
+		err = fmt.Errorf("failed to hash password: %w", err)
+		return
+	}
+
+	return string(hash), nil
+}
+
+// Verify implements signature.Verifier.
+func (b *Bcrypt) Verify(pw string, hash string) (err error) {
+	pwBytes, err := b.decodePassword(pw)
+	if err != nil {
+		return
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(hash), pwBytes)
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return signature.ErrMismatchedPassword
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decode hash: %w", err)
+	}
+
+	return nil
+}
+
+// NeedsRehash reports whether hash was produced with a cost other than
+// that of the hasher.
+func (b *Bcrypt) NeedsRehash(hash string) (bool, error) {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return false, fmt.Errorf("failed to decode hash: %w", err)
+	}
+
+	return cost != b.cost, nil
+}
+
+func (b *Bcrypt) decodePassword(pw string) ([]byte, error) {
+	pwBytes, err := b.pwCoder.Decode(pw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode password: %w", err)
+	}
+
+	if b.preHash {
+		// base64 as bcrypt stops at NUL bytes.
+		digest := sha256.Sum256(pwBytes)
+		return []byte(base64.StdEncoding.EncodeToString(digest[:])), nil
+	}
+
+	if len(pwBytes) > MaxPasswordLength {
+		return nil, ErrPasswordTooLong
+	}
+
+	return pwBytes, nil
+}
+
+// SignContext signs password unless ctx is done, see signature.SignContext.
+func (b *Bcrypt) SignContext(ctx context.Context, pw string) (string, error) {
+	return signature.SignContext(ctx, b, pw)
+}
+
+// VerifyContext verifies password against hash unless ctx is done, see
+// signature.VerifyContext.
+func (b *Bcrypt) VerifyContext(ctx context.Context, pw string, hash string) error {
+	return signature.VerifyContext(ctx, b, pw, hash)
+}
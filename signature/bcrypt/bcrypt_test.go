@@ -0,0 +1,133 @@
+package bcrypt
+
+import (
+	"strings"
+	"testing"
+
+	textcoder "github.com/imylam/text-coder"
+	"github.com/stretchr/testify/assert"
+)
+
+// Hash of "allmine" from the golang.org/x/crypto/bcrypt tests.
+const (
+	Password  = "allmine"
+	Signature = "$2a$10$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga"
+)
+
+var (
+	bcryptPw = NewBcrypt(MinCost, &textcoder.Utf8Coder{})
+)
+
+func TestAlgo(t *testing.T) {
+	assert.Equal(t, ALGO, bcryptPw.Algo())
+}
+
+func TestVerify(t *testing.T) {
+	err := bcryptPw.Verify(Password, Signature)
+	assert.NoError(t, err)
+}
+
+func TestVerifyOwnPasswordHash(t *testing.T) {
+	testPw := "lorem ipsum"
+
+	t.Run("GIVEN_same_password_WHEN_verifing_own_hash_THEN_no_error", func(t *testing.T) {
+		hash, err := bcryptPw.Sign(testPw)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hash, "$2a$04$"))
+
+		err = bcryptPw.Verify(testPw, hash)
+		assert.NoError(t, err)
+	})
+
+	t.Run("GIVEN_different_password_WHEN_verifing_own_hash_THEN_return_error", func(t *testing.T) {
+		hash, err := bcryptPw.Sign(testPw)
+		assert.NoError(t, err)
+
+		err = bcryptPw.Verify(Password, hash)
+
+		assert.ErrorContainsf(
+			t,
+			err,
+			ERR_MISMATCHED_PASSWORD,
+			"expected error containing %q, got %s", ERR_MISMATCHED_PASSWORD, err,
+		)
+	})
+}
+
+func TestLongPassword(t *testing.T) {
+	longPw := strings.Repeat("a", MaxPasswordLength) + "b"
+	preHashBcrypt := NewBcrypt(MinCost, &textcoder.Utf8Coder{}, WithPreHash())
+
+	t.Run("GIVEN_password_over_72_bytes_WHEN_signing_THEN_return_err", func(t *testing.T) {
+		hash, err := bcryptPw.Sign(longPw)
+
+		assert.Empty(t, hash)
+		assert.ErrorIs(t, err, ErrPasswordTooLong)
+	})
+
+	t.Run("GIVEN_password_over_72_bytes_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		hash, _ := bcryptPw.Sign(longPw[:MaxPasswordLength])
+
+		err := bcryptPw.Verify(longPw, hash)
+
+		assert.ErrorIs(t, err, ErrPasswordTooLong)
+	})
+
+	t.Run("GIVEN_pre_hash_WHEN_signing_password_over_72_bytes_THEN_verify_whole_password", func(t *testing.T) {
+		hash, err := preHashBcrypt.Sign(longPw)
+		assert.NoError(t, err)
+
+		assert.NoError(t, preHashBcrypt.Verify(longPw, hash))
+		assert.ErrorContains(t, preHashBcrypt.Verify(longPw[:MaxPasswordLength]+"c", hash), ERR_MISMATCHED_PASSWORD)
+	})
+}
+
+func TestNeedsRehash(t *testing.T) {
+	t.Run("GIVEN_hash_of_other_cost_WHEN_checking_THEN_return_true", func(t *testing.T) {
+		needsRehash, err := bcryptPw.NeedsRehash(Signature)
+
+		assert.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("GIVEN_hash_of_same_cost_WHEN_checking_THEN_return_false", func(t *testing.T) {
+		needsRehash, err := NewBcrypt(10, &textcoder.Utf8Coder{}).NeedsRehash(Signature)
+
+		assert.NoError(t, err)
+		assert.False(t, needsRehash)
+	})
+}
+
+func TestWrongPasswordOrHashShouldThrowError(t *testing.T) {
+	utf8Pw := "abc"
+	errDecodePw := "failed to decode password:"
+	errDecodeHash := "failed to decode hash:"
+
+	hexBcrypt := NewBcrypt(MinCost, &textcoder.HexCoder{})
+
+	t.Run("GIVEN_wrong_password_coding_WHEN_signing_THEN_return_err", func(t *testing.T) {
+		hash, err := hexBcrypt.Sign(utf8Pw)
+
+		assert.Empty(t, hash)
+		assert.ErrorContains(t, err, errDecodePw)
+	})
+
+	t.Run("GIVEN_wrong_password_coding_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		err := hexBcrypt.Verify(utf8Pw, Signature)
+
+		assert.ErrorContains(t, err, errDecodePw)
+	})
+
+	t.Run("GIVEN_malformatted_hash_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		err := bcryptPw.Verify(utf8Pw, "$2a$10$fooo")
+
+		assert.ErrorContains(t, err, errDecodeHash)
+	})
+
+	t.Run("GIVEN_invalid_cost_WHEN_signing_THEN_return_err", func(t *testing.T) {
+		hash, err := NewBcrypt(MaxCost+1, &textcoder.Utf8Coder{}).Sign(utf8Pw)
+
+		assert.Empty(t, hash)
+		assert.ErrorContains(t, err, "invalid cost")
+	})
+}
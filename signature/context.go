@@ -0,0 +1,72 @@
+package signature
+
+import "context"
+
+type ContextSigner interface {
+	Signer
+	SignContext(ctx context.Context, msg string) (string, error)
+}
+
+type ContextVerifier interface {
+	Verifier
+	VerifyContext(ctx context.Context, msg string, signature string) error
+}
+
+// SignContext signs msg with signer unless ctx is already done, and stops
+// waiting for the signature once ctx is done. As signing cannot be
+// interrupted, it runs to completion in the background.
+func SignContext(ctx context.Context, signer Signer, msg string) (string, error) {
+	var signature string
+	var signErr error
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	err := Run(ctx, func() {
+		signature, signErr = signer.Sign(msg)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return signature, signErr
+}
+
+// VerifyContext verifies msg against signature with verifier unless ctx
+// is already done, and stops waiting for the result once ctx is done. As
+// verifying cannot be interrupted, it runs to completion in the background.
+func VerifyContext(ctx context.Context, verifier Verifier, msg string, signature string) error {
+	var verifyErr error
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	err := Run(ctx, func() {
+		verifyErr = verifier.Verify(msg, signature)
+	})
+	if err != nil {
+		return err
+	}
+
+	return verifyErr
+}
+
+// Run runs f in a goroutine and waits for f to return, or for ctx to be
+// done while f runs to completion in the background. Callers check ctx
+// before Run to refuse to start work after cancellation.
+func Run(ctx context.Context, f func()) error {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,129 @@
+package signature
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type slowSigner struct {
+	delay time.Duration
+	calls int
+}
+
+func (s *slowSigner) Algo() string { return "slow" }
+
+func (s *slowSigner) Sign(msg string) (string, error) {
+	s.calls++
+	time.Sleep(s.delay)
+	return msg, nil
+}
+
+func (s *slowSigner) Verify(msg string, signature string) error {
+	s.calls++
+	time.Sleep(s.delay)
+	if msg != signature {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func TestSignContext(t *testing.T) {
+	t.Run("GIVEN_live_context_WHEN_signing_THEN_return_signature", func(t *testing.T) {
+		signer := &slowSigner{}
+
+		sig, err := SignContext(context.Background(), signer, "message")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "message", sig)
+	})
+
+	t.Run("GIVEN_canceled_context_WHEN_signing_THEN_return_err_without_signing", func(t *testing.T) {
+		signer := &slowSigner{}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := SignContext(ctx, signer, "message")
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 0, signer.calls)
+	})
+
+	t.Run("GIVEN_deadline_WHEN_signing_takes_longer_THEN_stop_waiting", func(t *testing.T) {
+		signer := &slowSigner{delay: time.Second}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := SignContext(ctx, signer, "message")
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), signer.delay)
+	})
+}
+
+func TestVerifyContext(t *testing.T) {
+	t.Run("GIVEN_live_context_WHEN_verifying_THEN_return_verify_result", func(t *testing.T) {
+		verifier := &slowSigner{}
+
+		assert.NoError(t, VerifyContext(context.Background(), verifier, "message", "message"))
+		assert.Error(t, VerifyContext(context.Background(), verifier, "message", "other"))
+	})
+
+	t.Run("GIVEN_canceled_context_WHEN_verifying_THEN_return_err_without_verifying", func(t *testing.T) {
+		verifier := &slowSigner{}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := VerifyContext(ctx, verifier, "message", "message")
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 0, verifier.calls)
+	})
+
+	t.Run("GIVEN_deadline_WHEN_verifying_takes_longer_THEN_stop_waiting", func(t *testing.T) {
+		verifier := &slowSigner{delay: time.Second}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := VerifyContext(ctx, verifier, "message", "message")
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), verifier.delay)
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("GIVEN_f_returning_WHEN_running_THEN_return_nil", func(t *testing.T) {
+		ran := false
+
+		err := Run(context.Background(), func() { ran = true })
+
+		assert.NoError(t, err)
+		assert.True(t, ran)
+	})
+
+	t.Run("GIVEN_context_done_while_f_runs_WHEN_running_THEN_return_context_err_and_complete_f", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		release := make(chan struct{})
+		done := make(chan struct{})
+
+		go func() {
+			<-release
+			cancel()
+		}()
+
+		err := Run(ctx, func() {
+			close(release)
+			time.Sleep(10 * time.Millisecond)
+			close(done)
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		<-done
+	})
+}
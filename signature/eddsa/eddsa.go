@@ -0,0 +1,7 @@
+package eddsa
+
+const (
+	ALGO                  = "EdDSA"
+	ERR_INVALID_KEY_SIZE  = "invalid key size"
+	ERR_INVALID_SIGNATURE = "invalid signature"
+)
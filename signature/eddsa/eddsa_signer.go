@@ -0,0 +1,57 @@
+package eddsa
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/imylam/crypto-utils/signature"
+	textcoder "github.com/imylam/text-coder"
+)
+
+type signer struct {
+	privateKey ed25519.PrivateKey
+	msgCoder   textcoder.Coder
+	sigCoder   textcoder.Coder
+}
+
+// NewSigner creates signer which sign message
+// with Ed25519 private key.
+//
+// Implements signature.Signer.
+func NewSigner(privateKey ed25519.PrivateKey, msgCoder textcoder.Coder, sigCoder textcoder.Coder) signer {
+	return signer{
+		privateKey: privateKey,
+		msgCoder:   msgCoder,
+		sigCoder:   sigCoder,
+	}
+}
+
+// Algo returns the algorithm used for signing.
+func (s signer) Algo() string {
+	return ALGO
+}
+
+// Sign message and return signature.
+func (s signer) Sign(msg string) (signature string, err error) {
+	msgBytes, err := s.msgCoder.Decode(msg)
+	if err != nil {
+		err = fmt.Errorf("failed to decode message: %w", err)
+		return
+	}
+
+	if len(s.privateKey) != ed25519.PrivateKeySize {
+		return "", fmt.Errorf("failed to sign message: %w", errors.New(ERR_INVALID_KEY_SIZE))
+	}
+
+	sigBytes := ed25519.Sign(s.privateKey, msgBytes)
+	signature = s.sigCoder.Encode(sigBytes)
+
+	return
+}
+
+// SignContext signs message unless ctx is done, see signature.SignContext.
+func (s signer) SignContext(ctx context.Context, msg string) (string, error) {
+	return signature.SignContext(ctx, s, msg)
+}
@@ -0,0 +1,165 @@
+package eddsa
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	textcoder "github.com/imylam/text-coder"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test vector TEST 2 of RFC 8032, section 7.1.
+const (
+	Seed             = "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb"
+	PublicKey        = "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c"
+	Message          = "72"
+	Signature        = "92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00"
+	AnotherSignature = "92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c01"
+)
+
+var (
+	seed, _    = hex.DecodeString(Seed)
+	pubKey, _  = hex.DecodeString(PublicKey)
+	privateKey = ed25519.NewKeyFromSeed(seed)
+	publicKey  = ed25519.PublicKey(pubKey)
+	edSigner   = NewSigner(privateKey, &textcoder.HexCoder{}, &textcoder.HexCoder{})
+	edVerifier = NewVerifier(publicKey, &textcoder.HexCoder{}, &textcoder.HexCoder{})
+)
+
+func TestAlgo(t *testing.T) {
+	sAlgo := edSigner.Algo()
+	assert.Equal(t, ALGO, sAlgo)
+
+	vAlgo := edVerifier.Algo()
+	assert.Equal(t, ALGO, vAlgo)
+}
+
+func TestSign(t *testing.T) {
+	sig, err := edSigner.Sign(Message)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Signature, sig)
+}
+
+func TestVerify(t *testing.T) {
+	err := edVerifier.Verify(Message, Signature)
+
+	assert.NoError(t, err)
+}
+
+func TestVerifyWrongSignatureShouldThrowError(t *testing.T) {
+	err := edVerifier.Verify(Message, AnotherSignature)
+
+	expectedErrMsg := "failed to verify signature:"
+	assert.ErrorContainsf(
+		t,
+		err,
+		expectedErrMsg,
+		"expected error containing %q, got %s", expectedErrMsg, err,
+	)
+}
+
+func TestWrongMessageOrSignatureCodingShouldThrowError(t *testing.T) {
+
+	utf8Msg := "abc"
+	utf8Signature := "hello"
+	errDecodeMsg := "failed to decode message:"
+	errDecodeSig := "failed to decode signature:"
+
+	t.Run("GIVEN_wrong_message_coding_WHEN_signing_THEN_return_err", func(t *testing.T) {
+		sig, err := edSigner.Sign(utf8Msg)
+
+		assert.Empty(t, sig)
+		assert.ErrorContainsf(
+			t,
+			err,
+			errDecodeMsg,
+			"expected error containing %q, got %s", errDecodeMsg, err,
+		)
+	})
+
+	t.Run("GIVEN_wrong_message_coding_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		err := edVerifier.Verify(utf8Msg, Signature)
+
+		assert.ErrorContainsf(
+			t,
+			err,
+			errDecodeMsg,
+			"expected error containing %q, got %s", errDecodeMsg, err,
+		)
+	})
+
+	t.Run("GIVEN_wrong_signature_coding_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		err := edVerifier.Verify(Message, utf8Signature)
+
+		assert.ErrorContainsf(
+			t,
+			err,
+			errDecodeSig,
+			"expected error containing %q, got %s", errDecodeSig, err,
+		)
+	})
+}
+
+func TestInvalidKeySizeShouldThrowError(t *testing.T) {
+	expectedErrMsg := ERR_INVALID_KEY_SIZE
+
+	t.Run("GIVEN_truncated_private_key_WHEN_signing_THEN_return_err", func(t *testing.T) {
+		testSigner := NewSigner(privateKey[:10], &textcoder.HexCoder{}, &textcoder.HexCoder{})
+		sig, err := testSigner.Sign(Message)
+
+		assert.Empty(t, sig)
+		assert.ErrorContainsf(
+			t,
+			err,
+			expectedErrMsg,
+			"expected error containing %q, got %s", expectedErrMsg, err,
+		)
+	})
+
+	t.Run("GIVEN_truncated_public_key_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		testVerifier := NewVerifier(publicKey[:10], &textcoder.HexCoder{}, &textcoder.HexCoder{})
+		err := testVerifier.Verify(Message, Signature)
+
+		assert.ErrorContainsf(
+			t,
+			err,
+			expectedErrMsg,
+			"expected error containing %q, got %s", expectedErrMsg, err,
+		)
+	})
+}
+
+func TestVerifyOwnSignedDigest(t *testing.T) {
+	testMsg := "lorem ipsum"
+
+	testPubKey, testPriKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	testSinger := NewSigner(testPriKey, &textcoder.Utf8Coder{}, &textcoder.Base64RawUrlCoder{})
+	testVerifier := NewVerifier(testPubKey, &textcoder.Utf8Coder{}, &textcoder.Base64RawUrlCoder{})
+
+	t.Run("GIVEN_same_message_WHEN_verifing_own_signed_signature_THEN_no_error", func(t *testing.T) {
+		sig, err := testSinger.Sign(testMsg)
+		assert.NoError(t, err)
+
+		err = testVerifier.Verify(testMsg, sig)
+		assert.NoError(t, err)
+	})
+
+	t.Run("GIVEN_different_message_WHEN_verifing_own_signed_signature_THEN_return_error", func(t *testing.T) {
+		sig, err := testSinger.Sign(testMsg)
+		assert.NoError(t, err)
+
+		err = testVerifier.Verify("message", sig)
+
+		expectedErrMsg := "failed to verify signature:"
+		assert.ErrorContainsf(
+			t,
+			err,
+			expectedErrMsg,
+			"expected error containing %q, got %s", expectedErrMsg, err,
+		)
+	})
+}
@@ -0,0 +1,66 @@
+package eddsa
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/imylam/crypto-utils/signature"
+	textcoder "github.com/imylam/text-coder"
+)
+
+type verifier struct {
+	publicKey ed25519.PublicKey
+	msgCoder  textcoder.Coder
+	sigCoder  textcoder.Coder
+}
+
+// NewVerifier creates verifier which verify signature of message
+// with Ed25519 public key.
+//
+// Implements signature.Verifier.
+func NewVerifier(publicKey ed25519.PublicKey, msgCoder textcoder.Coder, sigCoder textcoder.Coder) verifier {
+	return verifier{
+		publicKey: publicKey,
+		msgCoder:  msgCoder,
+		sigCoder:  sigCoder,
+	}
+}
+
+// Algo returns the algorithm used for verifying.
+func (s verifier) Algo() string {
+	return ALGO
+}
+
+// Verify message against signature.
+func (s verifier) Verify(msg string, signature string) (err error) {
+
+	msgBytes, err := s.msgCoder.Decode(msg)
+	if err != nil {
+		err = fmt.Errorf("failed to decode message: %w", err)
+		return
+	}
+
+	sigBytes, err := s.sigCoder.Decode(signature)
+	if err != nil {
+		err = fmt.Errorf("failed to decode signature: %w", err)
+		return
+	}
+
+	if len(s.publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("failed to verify signature: %w", errors.New(ERR_INVALID_KEY_SIZE))
+	}
+
+	if !ed25519.Verify(s.publicKey, msgBytes, sigBytes) {
+		err = fmt.Errorf("failed to verify signature: %w", errors.New(ERR_INVALID_SIGNATURE))
+	}
+
+	return
+}
+
+// VerifyContext verifies message against signature unless ctx is done,
+// see signature.VerifyContext.
+func (s verifier) VerifyContext(ctx context.Context, msg string, sig string) error {
+	return signature.VerifyContext(ctx, s, msg, sig)
+}
@@ -0,0 +1,18 @@
+package es256
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	_ "crypto/sha256" // registers crypto.SHA256
+
+	"github.com/imylam/crypto-utils/ecdsa"
+)
+
+const ALGO string = "ES256"
+
+var (
+	hash                  = crypto.SHA256
+	curve                 = elliptic.P256()
+	joseSignatureEncoding = ecdsa.NewJoseSignatureEncoding()
+	asn1SignatureEncoding = ecdsa.NewAsn1SignatureEncoding()
+)
@@ -0,0 +1,18 @@
+package es384
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	_ "crypto/sha512" // registers crypto.SHA384
+
+	"github.com/imylam/crypto-utils/ecdsa"
+)
+
+const ALGO string = "ES384"
+
+var (
+	hash                  = crypto.SHA384
+	curve                 = elliptic.P384()
+	joseSignatureEncoding = ecdsa.NewJoseSignatureEncoding()
+	asn1SignatureEncoding = ecdsa.NewAsn1SignatureEncoding()
+)
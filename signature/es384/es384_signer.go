@@ -0,0 +1,84 @@
+package es384
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"fmt"
+
+	ecdsaUtils "github.com/imylam/crypto-utils/ecdsa"
+	"github.com/imylam/crypto-utils/signature"
+	textcoder "github.com/imylam/text-coder"
+)
+
+type signer struct {
+	hash              crypto.Hash
+	curve             elliptic.Curve
+	signatureEncoding ecdsaUtils.SignatureEncoding
+	privateKey        *ecdsa.PrivateKey
+	msgCoder          textcoder.Coder
+	sigCoder          textcoder.Coder
+}
+
+// NewSigner creates signer which sign message
+// with ECDSA P-384 private key using SHA384 and encode signature as
+// fixed-length r||s as in JWS.
+//
+// Implements signature.Signer.
+func NewSigner(privateKey *ecdsa.PrivateKey, msgCoder textcoder.Coder, sigCoder textcoder.Coder) signer {
+	return newSigner(joseSignatureEncoding, privateKey, msgCoder, sigCoder)
+}
+
+// NewAsn1Signer creates signer which sign message
+// with ECDSA P-384 private key using SHA384 and encode signature in
+// ASN.1 DER form.
+//
+// Implements signature.Signer.
+func NewAsn1Signer(privateKey *ecdsa.PrivateKey, msgCoder textcoder.Coder, sigCoder textcoder.Coder) signer {
+	return newSigner(asn1SignatureEncoding, privateKey, msgCoder, sigCoder)
+}
+
+func newSigner(
+	signatureEncoding ecdsaUtils.SignatureEncoding,
+	privateKey *ecdsa.PrivateKey,
+	msgCoder textcoder.Coder,
+	sigCoder textcoder.Coder,
+) signer {
+	return signer{
+		hash:              hash,
+		curve:             curve,
+		signatureEncoding: signatureEncoding,
+		privateKey:        privateKey,
+		msgCoder:          msgCoder,
+		sigCoder:          sigCoder,
+	}
+}
+
+// Algo returns the algorithm used for signing.
+func (s signer) Algo() string {
+	return ALGO
+}
+
+// Sign message and return signature.
+func (s signer) Sign(msg string) (signature string, err error) {
+	msgBytes, err := s.msgCoder.Decode(msg)
+	if err != nil {
+		err = fmt.Errorf("failed to decode message: %w", err)
+		return
+	}
+
+	sigBytes, err := ecdsaUtils.Sign(s.hash, s.curve, s.signatureEncoding, s.privateKey, msgBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	signature = s.sigCoder.Encode(sigBytes)
+
+	return
+}
+
+// SignContext signs message unless ctx is done, see signature.SignContext.
+func (s signer) SignContext(ctx context.Context, msg string) (string, error) {
+	return signature.SignContext(ctx, s, msg)
+}
@@ -0,0 +1,90 @@
+package es384
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"fmt"
+
+	ecdsaUtils "github.com/imylam/crypto-utils/ecdsa"
+	"github.com/imylam/crypto-utils/signature"
+	textcoder "github.com/imylam/text-coder"
+)
+
+type verifier struct {
+	hash              crypto.Hash
+	curve             elliptic.Curve
+	signatureEncoding ecdsaUtils.SignatureEncoding
+	publicKey         *ecdsa.PublicKey
+	msgCoder          textcoder.Coder
+	sigCoder          textcoder.Coder
+}
+
+// NewVerifier creates verifier which verify signature of message
+// with ECDSA P-384 public key using SHA384, expecting signature as
+// fixed-length r||s as in JWS.
+//
+// Implements signature.Verifier.
+func NewVerifier(publicKey *ecdsa.PublicKey, msgCoder textcoder.Coder, sigCoder textcoder.Coder) verifier {
+	return newVerifier(joseSignatureEncoding, publicKey, msgCoder, sigCoder)
+}
+
+// NewAsn1Verifier creates verifier which verify signature of message
+// with ECDSA P-384 public key using SHA384, expecting signature in
+// ASN.1 DER form.
+//
+// Implements signature.Verifier.
+func NewAsn1Verifier(publicKey *ecdsa.PublicKey, msgCoder textcoder.Coder, sigCoder textcoder.Coder) verifier {
+	return newVerifier(asn1SignatureEncoding, publicKey, msgCoder, sigCoder)
+}
+
+func newVerifier(
+	signatureEncoding ecdsaUtils.SignatureEncoding,
+	publicKey *ecdsa.PublicKey,
+	msgCoder textcoder.Coder,
+	sigCoder textcoder.Coder,
+) verifier {
+	return verifier{
+		hash:              hash,
+		curve:             curve,
+		signatureEncoding: signatureEncoding,
+		publicKey:         publicKey,
+		msgCoder:          msgCoder,
+		sigCoder:          sigCoder,
+	}
+}
+
+// Algo returns the algorithm used for verifying.
+func (s verifier) Algo() string {
+	return ALGO
+}
+
+// Verify message against signature.
+func (s verifier) Verify(msg string, signature string) (err error) {
+
+	msgBytes, err := s.msgCoder.Decode(msg)
+	if err != nil {
+		err = fmt.Errorf("failed to decode message: %w", err)
+		return
+	}
+
+	sigBytes, err := s.sigCoder.Decode(signature)
+	if err != nil {
+		err = fmt.Errorf("failed to decode signature: %w", err)
+		return
+	}
+
+	err = ecdsaUtils.Verify(s.hash, s.curve, s.signatureEncoding, s.publicKey, msgBytes, sigBytes)
+	if err != nil {
+		err = fmt.Errorf("failed to verify signature: %w", err)
+	}
+
+	return
+}
+
+// VerifyContext verifies message against signature unless ctx is done,
+// see signature.VerifyContext.
+func (s verifier) VerifyContext(ctx context.Context, msg string, sig string) error {
+	return signature.VerifyContext(ctx, s, msg, sig)
+}
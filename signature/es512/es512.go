@@ -0,0 +1,18 @@
+package es512
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	_ "crypto/sha512" // registers crypto.SHA512
+
+	"github.com/imylam/crypto-utils/ecdsa"
+)
+
+const ALGO string = "ES512"
+
+var (
+	hash                  = crypto.SHA512
+	curve                 = elliptic.P521()
+	joseSignatureEncoding = ecdsa.NewJoseSignatureEncoding()
+	asn1SignatureEncoding = ecdsa.NewAsn1SignatureEncoding()
+)
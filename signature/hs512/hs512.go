@@ -1,10 +1,12 @@
 package hs512
 
 import (
+	"context"
 	"crypto"
 	"fmt"
 
 	"github.com/imylam/crypto-utils/hmac"
+	"github.com/imylam/crypto-utils/signature"
 	textcoder "github.com/imylam/text-coder"
 )
 
@@ -74,3 +76,14 @@ func (s HS512) Verify(msg, signature string) (err error) {
 	}
 	return
 }
+
+// SignContext signs message unless ctx is done, see signature.SignContext.
+func (s HS512) SignContext(ctx context.Context, msg string) (string, error) {
+	return signature.SignContext(ctx, s, msg)
+}
+
+// VerifyContext verifies message against signature unless ctx is done,
+// see signature.VerifyContext.
+func (s HS512) VerifyContext(ctx context.Context, msg string, sig string) error {
+	return signature.VerifyContext(ctx, s, msg, sig)
+}
@@ -0,0 +1,169 @@
+package limiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/imylam/crypto-utils/signature"
+)
+
+var (
+	ErrQueueFull = errors.New("too many hash operations queued")
+)
+
+var _ signature.PasswordHasher = (*Limiter)(nil)
+var _ signature.ContextSigner = (*Limiter)(nil)
+var _ signature.ContextVerifier = (*Limiter)(nil)
+
+// Limiter bounds the number of concurrent hash operations of a password
+// hasher, so that a burst of requests to memory-hard hashers like
+// argon2id and scrypt is queued or rejected instead of exhausting memory.
+type Limiter struct {
+	hasher   signature.PasswordHasher
+	slots    chan struct{}
+	maxQueue int32
+	queued   int32
+}
+
+// NewLimiter creates limiter which runs at most maxConcurrent Sign and
+// Verify of hasher at a time. Other calls wait for a slot, without limit
+// unless WithMaxQueue is given.
+//
+// Implements signature.PasswordHasher.
+func NewLimiter(
+	hasher signature.PasswordHasher,
+	maxConcurrent int,
+	options ...func(*Limiter),
+) *Limiter {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+
+	l := &Limiter{
+		hasher:   hasher,
+		slots:    make(chan struct{}, maxConcurrent),
+		maxQueue: -1,
+	}
+	for _, o := range options {
+		o(l)
+	}
+	return l
+}
+
+// WithMaxQueue rejects calls with ErrQueueFull when maxQueue calls are
+// already waiting for a slot. 0 rejects every call that cannot run
+// immediately.
+func WithMaxQueue(maxQueue int) func(*Limiter) {
+	return func(l *Limiter) {
+		l.maxQueue = int32(maxQueue)
+	}
+}
+
+// Algo returns the algorithm of the hasher.
+func (l *Limiter) Algo() string {
+	return l.hasher.Algo()
+}
+
+// Sign implements signature.Signer, waiting for a slot.
+func (l *Limiter) Sign(pw string) (string, error) {
+	return l.SignContext(context.Background(), pw)
+}
+
+// Verify implements signature.Verifier, waiting for a slot.
+func (l *Limiter) Verify(pw string, hash string) error {
+	return l.VerifyContext(context.Background(), pw, hash)
+}
+
+// SignContext signs pw once a slot is acquired, or returns the error of
+// ctx if it is done first. Once ctx is done it stops waiting for the
+// hash, while the slot is held until hashing completes in the background.
+func (l *Limiter) SignContext(ctx context.Context, pw string) (string, error) {
+	if err := l.acquire(ctx); err != nil {
+		return "", err
+	}
+
+	var hash string
+	var hashErr error
+
+	err := l.run(ctx, func() {
+		hash, hashErr = l.hasher.Sign(pw)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hash, hashErr
+}
+
+// VerifyContext verifies pw once a slot is acquired, or returns the error
+// of ctx if it is done first. Once ctx is done it stops waiting for the
+// result, while the slot is held until hashing completes in the
+// background.
+func (l *Limiter) VerifyContext(ctx context.Context, pw string, hash string) error {
+	if err := l.acquire(ctx); err != nil {
+		return err
+	}
+
+	var verifyErr error
+
+	err := l.run(ctx, func() {
+		verifyErr = l.hasher.Verify(pw, hash)
+	})
+	if err != nil {
+		return err
+	}
+
+	return verifyErr
+}
+
+// NeedsRehash implements signature.Rehasher without a slot, as it does
+// not hash.
+func (l *Limiter) NeedsRehash(hash string) (bool, error) {
+	return l.hasher.NeedsRehash(hash)
+}
+
+func (l *Limiter) acquire(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to acquire slot: %w", err)
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	queued := atomic.AddInt32(&l.queued, 1)
+	defer atomic.AddInt32(&l.queued, -1)
+
+	if l.maxQueue >= 0 && queued > l.maxQueue {
+		return ErrQueueFull
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to acquire slot: %w", ctx.Err())
+	}
+}
+
+// run runs f holding the acquired slot, releasing it once f returns,
+// unless ctx is done in the meantime. See signature.Run.
+func (l *Limiter) run(ctx context.Context, f func()) error {
+	if err := ctx.Err(); err != nil {
+		l.release()
+		return err
+	}
+
+	return signature.Run(ctx, func() {
+		defer l.release()
+		f()
+	})
+}
+
+func (l *Limiter) release() {
+	<-l.slots
+}
@@ -0,0 +1,152 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/imylam/crypto-utils/signature/bcrypt"
+	textcoder "github.com/imylam/text-coder"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingHasher blocks Sign and Verify until release is closed, counting
+// concurrent calls.
+type blockingHasher struct {
+	release    chan struct{}
+	running    int32
+	maxRunning int32
+}
+
+func newBlockingHasher() *blockingHasher {
+	return &blockingHasher{release: make(chan struct{})}
+}
+
+func (h *blockingHasher) Algo() string { return "blocking" }
+
+func (h *blockingHasher) Sign(pw string) (string, error) {
+	running := atomic.AddInt32(&h.running, 1)
+	defer atomic.AddInt32(&h.running, -1)
+
+	for {
+		max := atomic.LoadInt32(&h.maxRunning)
+		if running <= max || atomic.CompareAndSwapInt32(&h.maxRunning, max, running) {
+			break
+		}
+	}
+
+	<-h.release
+	return pw, nil
+}
+
+func (h *blockingHasher) Verify(pw string, hash string) error {
+	_, err := h.Sign(pw)
+	return err
+}
+
+func (h *blockingHasher) NeedsRehash(hash string) (bool, error) { return false, nil }
+
+func TestLimiter(t *testing.T) {
+	t.Run("GIVEN_max_concurrent_WHEN_signing_in_parallel_THEN_run_at_most_max_concurrent", func(t *testing.T) {
+		hasher := newBlockingHasher()
+		limiter := NewLimiter(hasher, 2)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := limiter.Sign("password")
+				assert.NoError(t, err)
+			}()
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		close(hasher.release)
+		wg.Wait()
+
+		assert.Equal(t, int32(2), hasher.maxRunning)
+	})
+
+	t.Run("GIVEN_no_free_slot_WHEN_context_is_done_THEN_return_context_err", func(t *testing.T) {
+		hasher := newBlockingHasher()
+		defer close(hasher.release)
+		limiter := NewLimiter(hasher, 1)
+		go limiter.Sign("password")
+		waitForRunning(hasher, 1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := limiter.VerifyContext(ctx, "password", "hash")
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("GIVEN_full_queue_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		hasher := newBlockingHasher()
+		defer close(hasher.release)
+		limiter := NewLimiter(hasher, 1, WithMaxQueue(0))
+		go limiter.Sign("password")
+		waitForRunning(hasher, 1)
+
+		err := limiter.Verify("password", "hash")
+
+		assert.ErrorIs(t, err, ErrQueueFull)
+	})
+
+	t.Run("GIVEN_canceled_context_WHEN_signing_THEN_return_err_without_hashing", func(t *testing.T) {
+		hasher := newBlockingHasher()
+		limiter := NewLimiter(hasher, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := limiter.SignContext(ctx, "password")
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int32(0), hasher.maxRunning)
+	})
+
+	t.Run("GIVEN_running_hash_WHEN_context_is_done_THEN_return_context_err_and_hold_slot", func(t *testing.T) {
+		hasher := newBlockingHasher()
+		limiter := NewLimiter(hasher, 1, WithMaxQueue(0))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := limiter.SignContext(ctx, "password")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		err = limiter.Verify("password", "hash")
+		assert.ErrorIs(t, err, ErrQueueFull)
+
+		close(hasher.release)
+		for atomic.LoadInt32(&hasher.running) > 0 || len(limiter.slots) > 0 {
+			time.Sleep(time.Millisecond)
+		}
+		assert.NoError(t, limiter.Verify("password", "hash"))
+	})
+}
+
+func TestLimiterWithBcrypt(t *testing.T) {
+	limiter := NewLimiter(bcrypt.NewBcrypt(bcrypt.MinCost, &textcoder.Utf8Coder{}), 1)
+
+	hash, err := limiter.Sign("password")
+	assert.NoError(t, err)
+
+	assert.Equal(t, bcrypt.ALGO, limiter.Algo())
+	assert.NoError(t, limiter.Verify("password", hash))
+
+	needsRehash, err := limiter.NeedsRehash(hash)
+	assert.NoError(t, err)
+	assert.False(t, needsRehash)
+}
+
+func waitForRunning(hasher *blockingHasher, n int32) {
+	for atomic.LoadInt32(&hasher.running) < n {
+		time.Sleep(time.Millisecond)
+	}
+}
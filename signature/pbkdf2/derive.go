@@ -0,0 +1,28 @@
+package pbkdf2
+
+import (
+	"crypto"
+	"errors"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// DeriveKey derives a key of keyLen bytes from password and salt with
+// PBKDF2, using HMAC with hash, crypto.SHA256 or crypto.SHA512, as
+// pseudorandom function.
+func DeriveKey(
+	hash crypto.Hash,
+	iterations int,
+	password, salt []byte,
+	keyLen int,
+) ([]byte, error) {
+	if hash != crypto.SHA256 && hash != crypto.SHA512 {
+		return nil, errors.New(errInvalidParams)
+	}
+
+	if iterations < 1 || iterations > maxIterations || keyLen < 1 {
+		return nil, errors.New(errInvalidParams)
+	}
+
+	return pbkdf2.Key(password, salt, iterations, keyLen, hash.New), nil
+}
@@ -0,0 +1,72 @@
+package pbkdf2
+
+import (
+	"crypto"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeriveKey(t *testing.T) {
+	testCases := []struct {
+		name       string
+		hash       crypto.Hash
+		iterations int
+		password   string
+		salt       string
+		key        string
+	}{
+		{
+			// RFC 7914, section 11.
+			name:       "GIVEN_rfc7914_vector_WHEN_deriving_THEN_return_key",
+			hash:       crypto.SHA256,
+			iterations: 1,
+			password:   "passwd",
+			salt:       "salt",
+			key: "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc" +
+				"49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783",
+		},
+		{
+			// Python's hashlib.pbkdf2_hmac.
+			name:       "GIVEN_sha512_WHEN_deriving_THEN_return_key",
+			hash:       crypto.SHA512,
+			iterations: 1000,
+			password:   "password",
+			salt:       "saltsaltsalt",
+			key:        "a845ae0473fcdaeccfaf26c2529a7d14c1950e8b336a00f03a0061018e38cb68",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := DeriveKey(tc.hash, tc.iterations, []byte(tc.password), []byte(tc.salt), len(tc.key)/2)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.key, hex.EncodeToString(key))
+		})
+	}
+}
+
+func TestDeriveKeyWithInvalidParamsShouldThrowError(t *testing.T) {
+	testCases := []struct {
+		name       string
+		hash       crypto.Hash
+		iterations int
+		keyLen     int
+	}{
+		{"GIVEN_unsupported_hash_WHEN_deriving_THEN_return_err", crypto.SHA1, 1000, 32},
+		{"GIVEN_zero_iterations_WHEN_deriving_THEN_return_err", crypto.SHA256, 0, 32},
+		{"GIVEN_iterations_over_max_WHEN_deriving_THEN_return_err", crypto.SHA256, maxIterations + 1, 32},
+		{"GIVEN_zero_key_length_WHEN_deriving_THEN_return_err", crypto.SHA256, 1000, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := DeriveKey(tc.hash, tc.iterations, []byte("password"), []byte("saltsalt"), tc.keyLen)
+
+			assert.Nil(t, key)
+			assert.ErrorContains(t, err, errInvalidParams)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package pbkdf2
+
+import (
+	"crypto"
+	"errors"
+)
+
+const (
+	errInvalidParams = "invalid parameters"
+	maxIterations    = 10_000_000 // the maximum iterations accepted in hashes.
+	minSaltLen       = 8          // the minimum allowed salt length in characters.
+	maxSaltLen       = 1024       // the maximum allowed salt length in characters.
+)
+
+var DefaultParams = Params{Hash: crypto.SHA256, Iterations: 600_000, SaltLen: 22}
+
+// Params describes the input parameters to the PBKDF2 key derivation
+// function as per RFC 8018, with HMAC as pseudorandom function.
+type Params struct {
+	Hash       crypto.Hash // crypto.SHA256 or crypto.SHA512
+	Iterations int         // iteration count
+	SaltLen    int         // characters to use as salt
+}
+
+func (p *Params) Check() error {
+	// Validate hash
+	if p.Hash != crypto.SHA256 && p.Hash != crypto.SHA512 {
+		return errors.New(errInvalidParams)
+	}
+
+	// Validate iterations
+	if p.Iterations < 1 || p.Iterations > maxIterations {
+		return errors.New(errInvalidParams)
+	}
+
+	// Validate the salt length
+	if p.SaltLen < minSaltLen || p.SaltLen > maxSaltLen {
+		return errors.New(errInvalidParams)
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package pbkdf2
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	_ "crypto/sha256" // registers crypto.SHA256
+	_ "crypto/sha512" // registers crypto.SHA512
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/imylam/crypto-utils/signature"
+	textcoder "github.com/imylam/text-coder"
+)
+
+const (
+	ALGO_SHA256             = "pbkdf2_sha256"
+	ALGO_SHA512             = "pbkdf2_sha512"
+	ERR_MALFOMATTED_HASH    = "malformatted hash provided"
+	ERR_MISMATCHED_PASSWORD = "hashed password does not match the hash of password provided"
+
+	saltChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+var _ signature.Signer = (*Pbkdf2)(nil)
+var _ signature.Verifier = (*Pbkdf2)(nil)
+var _ signature.PasswordHasher = (*Pbkdf2)(nil)
+var _ signature.ContextSigner = (*Pbkdf2)(nil)
+var _ signature.ContextVerifier = (*Pbkdf2)(nil)
+
+var algos = map[crypto.Hash]string{
+	crypto.SHA256: ALGO_SHA256,
+	crypto.SHA512: ALGO_SHA512,
+}
+
+type Pbkdf2 struct {
+	params  Params
+	pwCoder textcoder.Coder
+}
+
+// NewPbkdf2 creates password hasher which hash passwords with the params
+// given into the Django format "pbkdf2_sha256$<iterations>$<salt>$<hash>",
+// with hash in padded standard base64.
+//
+// Verify also accepts the werkzeug format
+// "pbkdf2:sha256:<iterations>$<salt>$<hex hash>".
+func NewPbkdf2(
+	params Params,
+	pwCoder textcoder.Coder,
+) *Pbkdf2 {
+	return &Pbkdf2{
+		params:  params,
+		pwCoder: pwCoder,
+	}
+}
+
+// Algo returns the algorithm used for signing/verifying.
+func (p *Pbkdf2) Algo() (algo string) {
+	return algos[p.params.Hash]
+}
+
+// Sign implements signature.Signer.
+func (p *Pbkdf2) Sign(
+	pw string,
+) (pwHash string, err error) {
+	if err = p.params.Check(); err != nil {
+		return
+	}
+
+	pwBytes, err := p.pwCoder.Decode(pw)
+	if err != nil {
+		err = fmt.Errorf("failed to decode password: %w", err)
+		return
+	}
+
+	salt, err := generateSalt(p.params.SaltLen)
+	if err != nil {
+		err = fmt.Errorf("failed to generate salt: %w", err)
+		return
+	}
+
+	dk, err := DeriveKey(p.params.Hash, p.params.Iterations, pwBytes, []byte(salt), p.params.Hash.Size())
+	if err != nil {
+		return
+	}
+
+	pwHash = fmt.Sprintf(
+		"%s$%d$%s$%s",
+		p.Algo(),
+		p.params.Iterations,
+		salt,
+		base64.StdEncoding.EncodeToString(dk),
+	)
+
+	return
+}
+
+// Verify implements signature.Verifier.
+func (p *Pbkdf2) Verify(pw string, hash string) (err error) {
+	params, salt, dk, err := decodeHash(hash)
+	if err != nil {
+		err = fmt.Errorf("failed to decode hash: %w", err)
+		return
+	}
+
+	pwBytes, err := p.pwCoder.Decode(pw)
+	if err != nil {
+		err = fmt.Errorf("failed to decode password: %w", err)
+		return
+	}
+
+	other, err := DeriveKey(params.Hash, params.Iterations, pwBytes, []byte(salt), len(dk))
+	if err != nil {
+		return
+	}
+
+	if subtle.ConstantTimeCompare(dk, other) != 1 {
+		err = signature.ErrMismatchedPassword
+		return
+	}
+
+	return
+}
+
+// NeedsRehash reports whether hash was produced with params other than
+// those of the hasher.
+func (p *Pbkdf2) NeedsRehash(hash string) (bool, error) {
+	params, _, _, err := decodeHash(hash)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode hash: %w", err)
+	}
+
+	return params != p.params, nil
+}
+
+func decodeHash(hash string) (Params, string, []byte, error) {
+	vals := strings.Split(hash, "$")
+
+	// algorithm, salt, derived key
+	if len(vals) == 3 && strings.HasPrefix(vals[0], "pbkdf2:") {
+		return decodeWerkzeugHash(vals)
+	}
+
+	// algorithm, iterations, salt, derived key
+	if len(vals) != 4 {
+		return Params{}, "", nil, errors.New(ERR_MALFOMATTED_HASH)
+	}
+
+	var params Params
+	var err error
+
+	switch vals[0] {
+	case ALGO_SHA256:
+		params.Hash = crypto.SHA256
+	case ALGO_SHA512:
+		params.Hash = crypto.SHA512
+	default:
+		return params, "", nil, fmt.Errorf(ERR_MALFOMATTED_HASH+" unsupported algorithm %q", vals[0])
+	}
+
+	params.Iterations, err = strconv.Atoi(vals[1])
+	if err != nil {
+		return params, "", nil, fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
+	}
+
+	salt := vals[2]
+	params.SaltLen = len(salt)
+
+	dk, err := base64.StdEncoding.DecodeString(vals[3])
+	if err != nil {
+		return params, "", nil, fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
+	}
+
+	if err := checkDecoded(params, dk); err != nil {
+		return params, "", nil, err
+	}
+
+	return params, salt, dk, nil
+}
+
+func decodeWerkzeugHash(vals []string) (Params, string, []byte, error) {
+	// pbkdf2, hash name, iterations
+	method := strings.Split(vals[0], ":")
+	if len(method) != 3 {
+		return Params{}, "", nil, errors.New(ERR_MALFOMATTED_HASH)
+	}
+
+	var params Params
+	var err error
+
+	switch method[1] {
+	case "sha256":
+		params.Hash = crypto.SHA256
+	case "sha512":
+		params.Hash = crypto.SHA512
+	default:
+		return params, "", nil, fmt.Errorf(ERR_MALFOMATTED_HASH+" unsupported algorithm %q", method[1])
+	}
+
+	params.Iterations, err = strconv.Atoi(method[2])
+	if err != nil {
+		return params, "", nil, fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
+	}
+
+	salt := vals[1]
+	params.SaltLen = len(salt)
+
+	dk, err := hex.DecodeString(vals[2])
+	if err != nil {
+		return params, "", nil, fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
+	}
+
+	if err := checkDecoded(params, dk); err != nil {
+		return params, "", nil, err
+	}
+
+	return params, salt, dk, nil
+}
+
+func checkDecoded(params Params, dk []byte) error {
+	if err := params.Check(); err != nil {
+		return err
+	}
+
+	if len(dk) != params.Hash.Size() {
+		return errors.New(ERR_MALFOMATTED_HASH)
+	}
+
+	return nil
+}
+
+// generateSalt returns n random alphanumeric characters, as salts of the
+// Django format must not contain "$".
+func generateSalt(n int) (string, error) {
+	max := big.NewInt(int64(len(saltChars)))
+	salt := make([]byte, n)
+
+	for i := range salt {
+		j, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		salt[i] = saltChars[j.Int64()]
+	}
+
+	return string(salt), nil
+}
+
+// SignContext signs password unless ctx is done, see signature.SignContext.
+func (p *Pbkdf2) SignContext(ctx context.Context, pw string) (string, error) {
+	return signature.SignContext(ctx, p, pw)
+}
+
+// VerifyContext verifies password against hash unless ctx is done, see
+// signature.VerifyContext.
+func (p *Pbkdf2) VerifyContext(ctx context.Context, pw string, hash string) error {
+	return signature.VerifyContext(ctx, p, pw, hash)
+}
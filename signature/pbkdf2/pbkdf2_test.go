@@ -0,0 +1,151 @@
+package pbkdf2
+
+import (
+	"crypto"
+	"strings"
+	"testing"
+
+	textcoder "github.com/imylam/text-coder"
+	"github.com/stretchr/testify/assert"
+)
+
+// Hashes of "password" produced by Python's hashlib.pbkdf2_hmac.
+const (
+	Password          = "password"
+	DjangoSignature   = "pbkdf2_sha256$600000$somesaltsomesalt$ivf+fieW0XSkO4ug266G8XCK0Fb1q2B2QH0uwQrFHLw="
+	WerkzeugSignature = "pbkdf2:sha512:1000$somesaltsomesalt$" +
+		"6b9c20a161483cab89384b33a8c10a7e9c493189a870446c1d7682e82bdd91d6" +
+		"9334290ee89c5c2ae0e83585e2de23c32e78e0242015b56b6c7ec7060190b43d"
+)
+
+var (
+	fastParams = Params{Hash: crypto.SHA512, Iterations: 1000, SaltLen: 16}
+	pbkdf2Pw   = NewPbkdf2(fastParams, &textcoder.Utf8Coder{})
+)
+
+func TestAlgo(t *testing.T) {
+	assert.Equal(t, ALGO_SHA512, pbkdf2Pw.Algo())
+	assert.Equal(t, ALGO_SHA256, NewPbkdf2(DefaultParams, &textcoder.Utf8Coder{}).Algo())
+}
+
+func TestVerify(t *testing.T) {
+	t.Run("GIVEN_django_hash_WHEN_verifying_THEN_no_error", func(t *testing.T) {
+		assert.NoError(t, pbkdf2Pw.Verify(Password, DjangoSignature))
+	})
+
+	t.Run("GIVEN_werkzeug_hash_WHEN_verifying_THEN_no_error", func(t *testing.T) {
+		assert.NoError(t, pbkdf2Pw.Verify(Password, WerkzeugSignature))
+	})
+
+	t.Run("GIVEN_wrong_password_WHEN_verifying_django_hash_THEN_return_err", func(t *testing.T) {
+		err := pbkdf2Pw.Verify("wrong password", DjangoSignature)
+
+		assert.ErrorContains(t, err, ERR_MISMATCHED_PASSWORD)
+	})
+}
+
+func TestVerifyOwnPasswordHash(t *testing.T) {
+	testPw := "lorem ipsum"
+
+	t.Run("GIVEN_same_password_WHEN_verifing_own_hash_THEN_no_error", func(t *testing.T) {
+		hash, err := pbkdf2Pw.Sign(testPw)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hash, "pbkdf2_sha512$1000$"))
+		assert.Len(t, strings.Split(hash, "$")[2], fastParams.SaltLen)
+
+		err = pbkdf2Pw.Verify(testPw, hash)
+		assert.NoError(t, err)
+	})
+
+	t.Run("GIVEN_different_password_WHEN_verifing_own_hash_THEN_return_error", func(t *testing.T) {
+		hash, err := pbkdf2Pw.Sign(testPw)
+		assert.NoError(t, err)
+
+		err = pbkdf2Pw.Verify(Password, hash)
+
+		assert.ErrorContainsf(
+			t,
+			err,
+			ERR_MISMATCHED_PASSWORD,
+			"expected error containing %q, got %s", ERR_MISMATCHED_PASSWORD, err,
+		)
+	})
+}
+
+func TestNeedsRehash(t *testing.T) {
+	t.Run("GIVEN_hash_of_other_params_WHEN_checking_THEN_return_true", func(t *testing.T) {
+		needsRehash, err := pbkdf2Pw.NeedsRehash(DjangoSignature)
+
+		assert.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("GIVEN_hash_of_same_params_WHEN_checking_THEN_return_false", func(t *testing.T) {
+		needsRehash, err := pbkdf2Pw.NeedsRehash(WerkzeugSignature)
+
+		assert.NoError(t, err)
+		assert.False(t, needsRehash)
+	})
+}
+
+func TestMalformattedHashShouldThrowError(t *testing.T) {
+	testCases := []struct {
+		name string
+		hash string
+	}{
+		{
+			name: "GIVEN_missing_field_WHEN_verifying_THEN_return_err",
+			hash: "pbkdf2_sha256$600000$somesaltsomesalt",
+		},
+		{
+			name: "GIVEN_unsupported_algorithm_WHEN_verifying_THEN_return_err",
+			hash: strings.Replace(DjangoSignature, "pbkdf2_sha256", "pbkdf2_sha1", 1),
+		},
+		{
+			name: "GIVEN_non_numeric_iterations_WHEN_verifying_THEN_return_err",
+			hash: strings.Replace(DjangoSignature, "600000", "abc", 1),
+		},
+		{
+			name: "GIVEN_iterations_over_max_WHEN_verifying_THEN_return_err",
+			hash: strings.Replace(DjangoSignature, "600000", "100000000", 1),
+		},
+		{
+			name: "GIVEN_truncated_hash_WHEN_verifying_THEN_return_err",
+			hash: strings.TrimSuffix(DjangoSignature, "HLw="),
+		},
+		{
+			name: "GIVEN_werkzeug_hash_without_iterations_WHEN_verifying_THEN_return_err",
+			hash: strings.Replace(WerkzeugSignature, "pbkdf2:sha512:1000", "pbkdf2:sha512", 1),
+		},
+		{
+			name: "GIVEN_werkzeug_hash_with_non_hex_hash_WHEN_verifying_THEN_return_err",
+			hash: WerkzeugSignature[:len(WerkzeugSignature)-1] + "x",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := pbkdf2Pw.Verify(Password, tc.hash)
+
+			assert.ErrorContains(t, err, "failed to decode hash:")
+		})
+	}
+}
+
+func TestWrongPasswordCodingShouldThrowError(t *testing.T) {
+	errDecodePw := "failed to decode password:"
+	hexPbkdf2 := NewPbkdf2(fastParams, &textcoder.HexCoder{})
+
+	t.Run("GIVEN_wrong_password_coding_WHEN_signing_THEN_return_err", func(t *testing.T) {
+		hash, err := hexPbkdf2.Sign("abc")
+
+		assert.Empty(t, hash)
+		assert.ErrorContains(t, err, errDecodePw)
+	})
+
+	t.Run("GIVEN_wrong_password_coding_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		err := hexPbkdf2.Verify("abc", DjangoSignature)
+
+		assert.ErrorContains(t, err, errDecodePw)
+	})
+}
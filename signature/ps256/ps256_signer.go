@@ -1,11 +1,13 @@
 package ps256
 
 import (
+	"context"
 	"crypto"
 	"crypto/rsa"
 	"fmt"
 
 	rsaUtils "github.com/imylam/crypto-utils/rsa"
+	"github.com/imylam/crypto-utils/signature"
 	textcoder "github.com/imylam/text-coder"
 )
 
@@ -53,3 +55,8 @@ func (s signer) Sign(msg string) (signature string, err error) {
 
 	return
 }
+
+// SignContext signs message unless ctx is done, see signature.SignContext.
+func (s signer) SignContext(ctx context.Context, msg string) (string, error) {
+	return signature.SignContext(ctx, s, msg)
+}
@@ -1,11 +1,13 @@
 package ps512
 
 import (
+	"context"
 	"crypto"
 	"crypto/rsa"
 	"fmt"
 
 	rsaUtils "github.com/imylam/crypto-utils/rsa"
+	"github.com/imylam/crypto-utils/signature"
 	textcoder "github.com/imylam/text-coder"
 )
 
@@ -58,3 +60,9 @@ func (s verifier) Verify(msg string, signature string) (err error) {
 
 	return
 }
+
+// VerifyContext verifies message against signature unless ctx is done,
+// see signature.VerifyContext.
+func (s verifier) VerifyContext(ctx context.Context, msg string, sig string) error {
+	return signature.VerifyContext(ctx, s, msg, sig)
+}
@@ -0,0 +1,42 @@
+package signature
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMismatchedPassword is returned by Verify of the password hashers of
+// this module when the password does not match the hash, as opposed to
+// the hash failing to decode.
+var ErrMismatchedPassword = errors.New("hashed password does not match the hash of password provided")
+
+type Rehasher interface {
+	NeedsRehash(hash string) (bool, error)
+}
+
+type PasswordHasher interface {
+	Signer
+	Verifier
+	Rehasher
+}
+
+// VerifyAndUpgrade verifies pw against hash and, when it matches but the
+// hash was produced with outdated parameters, returns a fresh hash of pw.
+// newHash is empty if hash is up to date.
+func VerifyAndUpgrade(hasher PasswordHasher, pw, hash string) (newHash string, err error) {
+	if err = hasher.Verify(pw, hash); err != nil {
+		return
+	}
+
+	needsRehash, err := hasher.NeedsRehash(hash)
+	if err != nil || !needsRehash {
+		return
+	}
+
+	newHash, err = hasher.Sign(pw)
+	if err != nil {
+		err = fmt.Errorf("failed to rehash password: %w", err)
+	}
+
+	return
+}
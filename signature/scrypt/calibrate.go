@@ -0,0 +1,78 @@
+package scrypt
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/imylam/crypto-utils/internal/calibrate"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	ERR_CALIBRATION_FAILED = "no scrypt params meet the target latency"
+	calibrationR           = 8
+	minCalibrationN        = 1 << 10
+)
+
+// measure is replaced in tests to simulate hardware.
+var measure = func(params Params) time.Duration {
+	salt := make([]byte, params.SaltLen)
+
+	return calibrate.Time(func() {
+		scrypt.Key([]byte("password"), salt, params.N, params.R, params.P, params.DKLen)
+	})
+}
+
+// Calibrate benchmarks the current machine and returns the strongest
+// params hashing within targetLatency, using at most maxMemory bytes of
+// memory (128 * N * r), capped by MaxMemory.
+//
+// N is searched from the largest power of 2 fitting maxMemory before p,
+// as scrypt computes p sequentially, see calibrate.Search. SaltLen and
+// DKLen are those of DefaultParams.
+func Calibrate(
+	targetLatency time.Duration,
+	maxMemory int,
+) (Params, error) {
+	params := DefaultParams
+	params.R = calibrationR
+	params.P = 1
+
+	if maxMemory > MaxMemory {
+		maxMemory = MaxMemory
+	}
+
+	params.N = minCalibrationN
+	if 128*params.N*params.R > maxMemory {
+		return Params{}, errors.New(errInvalidParams)
+	}
+	for 128*(params.N*2)*params.R <= maxMemory {
+		params.N *= 2
+	}
+
+	n, p, ok := calibrate.Search(
+		targetLatency,
+		params.N,
+		minCalibrationN,
+		MaxP,
+		func(n, p int) time.Duration {
+			candidate := params
+			candidate.N = n
+			candidate.P = p
+			return measure(candidate)
+		},
+	)
+	if !ok {
+		return Params{}, fmt.Errorf(ERR_CALIBRATION_FAILED+" %s", targetLatency)
+	}
+
+	params.N = n
+	params.P = p
+
+	if err := params.Check(); err != nil {
+		return Params{}, err
+	}
+
+	return params, nil
+}
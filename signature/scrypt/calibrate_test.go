@@ -0,0 +1,79 @@
+package scrypt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// simulateHardware replaces measure by a machine hashing 1 MiB in perMiB.
+func simulateHardware(t *testing.T, perMiB time.Duration) {
+	original := measure
+	t.Cleanup(func() { measure = original })
+
+	measure = func(params Params) time.Duration {
+		return time.Duration(params.P) * time.Duration(128*params.N*params.R) * perMiB / (1 << 20)
+	}
+}
+
+func TestCalibrate(t *testing.T) {
+	t.Run("GIVEN_memory_budget_within_latency_WHEN_calibrating_THEN_use_all_memory_and_raise_p", func(t *testing.T) {
+		simulateHardware(t, time.Millisecond)
+
+		params, err := Calibrate(100*time.Millisecond, 32<<20)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Params{N: 32768, R: 8, P: 3, SaltLen: DefaultParams.SaltLen, DKLen: DefaultParams.DKLen}, params)
+	})
+
+	t.Run("GIVEN_memory_budget_over_latency_WHEN_calibrating_THEN_halve_n", func(t *testing.T) {
+		simulateHardware(t, time.Millisecond)
+
+		params, err := Calibrate(20*time.Millisecond, 1<<30)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Params{N: 16384, R: 8, P: 1, SaltLen: DefaultParams.SaltLen, DKLen: DefaultParams.DKLen}, params)
+	})
+
+	t.Run("GIVEN_fast_machine_WHEN_calibrating_THEN_stay_within_limits", func(t *testing.T) {
+		simulateHardware(t, time.Microsecond)
+
+		params, err := Calibrate(time.Second, 1<<30)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Params{N: MaxMemory / 128 / 8, R: 8, P: MaxP, SaltLen: DefaultParams.SaltLen, DKLen: DefaultParams.DKLen}, params)
+		assert.NoError(t, params.checkCost(MaxMemory, MaxR, MaxP))
+	})
+
+	t.Run("GIVEN_clock_measuring_0_WHEN_calibrating_THEN_return_max_p", func(t *testing.T) {
+		simulateHardware(t, 0)
+
+		params, err := Calibrate(time.Second, 32<<20)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Params{N: 32768, R: 8, P: MaxP, SaltLen: DefaultParams.SaltLen, DKLen: DefaultParams.DKLen}, params)
+	})
+
+	t.Run("GIVEN_unreachable_latency_WHEN_calibrating_THEN_return_err", func(t *testing.T) {
+		simulateHardware(t, time.Second)
+
+		_, err := Calibrate(time.Millisecond, 32<<20)
+
+		assert.ErrorContains(t, err, ERR_CALIBRATION_FAILED)
+	})
+
+	t.Run("GIVEN_memory_budget_under_minimum_WHEN_calibrating_THEN_return_err", func(t *testing.T) {
+		_, err := Calibrate(time.Second, 1024)
+
+		assert.ErrorContains(t, err, errInvalidParams)
+	})
+
+	t.Run("GIVEN_this_machine_WHEN_calibrating_THEN_return_valid_params", func(t *testing.T) {
+		params, err := Calibrate(100*time.Millisecond, 8<<20)
+
+		assert.NoError(t, err)
+		assert.NoError(t, params.Check())
+		assert.LessOrEqual(t, 128*params.N*params.R, 8<<20)
+	})
+}
@@ -3,10 +3,23 @@ package scrypt
 import "errors"
 
 const (
-	errInvalidParams = "invalid parameters"
-	maxInt           = 1<<31 - 1
-	minDKLen         = 16 // the minimum derived key length in bytes.
-	minSaltLen       = 8  // the minimum allowed salt length in bytes.
+	// Default upper bounds on params read from hashes, so that a hostile
+	// hash cannot make Verify spend unbounded time or memory. A hasher
+	// raises them to its own params, and WithMaxCost replaces them.
+	// Calibrate keeps within them.
+	//
+	// Breaking change: hashes over these bounds, which earlier versions
+	// verified, are rejected unless the hasher's params or WithMaxCost
+	// allow them.
+	MaxMemory = 1 << 28 // bytes used by scrypt, 128 * N * r
+	MaxR      = 32
+	MaxP      = 16
+
+	errInvalidParams    = "invalid parameters"
+	errParamsOutOfRange = "parameters out of range"
+	maxInt              = 1<<31 - 1
+	minDKLen            = 16 // the minimum derived key length in bytes.
+	minSaltLen          = 8  // the minimum allowed salt length in bytes.
 )
 
 var DefaultParams = Params{N: 32768, R: 8, P: 1, SaltLen: 8, DKLen: 32}
@@ -56,3 +69,18 @@ func (p *Params) Check() error {
 
 	return nil
 }
+
+// checkCost returns an error if p uses more than maxMemory bytes of
+// memory (128 * N * r), or exceeds maxR or maxP.
+func (p *Params) checkCost(maxMemory, maxR, maxP int) error {
+	if p.R > maxR || p.P > maxP || p.memory() > int64(maxMemory) {
+		return errors.New(errParamsOutOfRange)
+	}
+
+	return nil
+}
+
+// memory returns the bytes of memory used by scrypt with p, 128 * N * r.
+func (p *Params) memory() int64 {
+	return int64(p.N) * int64(p.R) * 128
+}
@@ -0,0 +1,16 @@
+package scrypt
+
+// WithPepper is WithPhcFormat, with passwords peppered by hmac.Pepper
+// with the pepper of keyID in keyring before scrypt and keyID embedded in
+// the hash as "pepper=<keyID>" parameter. Hashes without pepper are
+// verified without pepper.
+func WithPepper(
+	keyID string,
+	keyring map[string][]byte,
+) func(*Scrypt) {
+	return func(s *Scrypt) {
+		s.phcFormat = true
+		s.pepperID = keyID
+		s.peppers = keyring
+	}
+}
@@ -0,0 +1,77 @@
+package scrypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/imylam/crypto-utils/hmac"
+	textcoder "github.com/imylam/text-coder"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	pepperKeyring = map[string][]byte{
+		"2023": []byte("old pepper"),
+		"2024": []byte("new pepper"),
+	}
+)
+
+func TestPepper(t *testing.T) {
+	oldPepper := NewScrypt(DefaultParams, &textcoder.Utf8Coder{}, &textcoder.HexCoder{}, WithPepper("2023", pepperKeyring))
+	newPepper := NewScrypt(DefaultParams, &textcoder.Utf8Coder{}, &textcoder.HexCoder{}, WithPepper("2024", pepperKeyring))
+
+	oldHash, err := oldPepper.Sign(Password)
+	assert.NoError(t, err)
+
+	t.Run("GIVEN_pepper_WHEN_signing_THEN_embed_pepper_key_id_in_phc_hash", func(t *testing.T) {
+		assert.True(t, strings.HasPrefix(oldHash, "$scrypt$ln=15,r=8,p=1,pepper=2023$"))
+	})
+
+	t.Run("GIVEN_hash_of_old_pepper_WHEN_verifying_with_keyring_THEN_no_error", func(t *testing.T) {
+		assert.NoError(t, newPepper.Verify(Password, oldHash))
+	})
+
+	t.Run("GIVEN_hash_of_old_pepper_WHEN_checking_THEN_return_true", func(t *testing.T) {
+		needsRehash, err := newPepper.NeedsRehash(oldHash)
+
+		assert.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("GIVEN_peppered_hash_WHEN_verifying_without_pepper_THEN_return_err", func(t *testing.T) {
+		err := scryptPw.Verify(Password, oldHash)
+
+		assert.ErrorIs(t, err, hmac.ErrUnknownPepper)
+	})
+
+	t.Run("GIVEN_peppered_hash_with_pepper_removed_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		err := newPepper.Verify(Password, strings.Replace(oldHash, ",pepper=2023", "", 1))
+
+		assert.ErrorContains(t, err, "hashed password does not match the hash of password provided")
+	})
+
+	t.Run("GIVEN_hash_without_pepper_WHEN_verifying_with_pepper_THEN_no_error_and_need_rehash", func(t *testing.T) {
+		hash, err := scryptPw.Sign(Password)
+		assert.NoError(t, err)
+
+		assert.NoError(t, newPepper.Verify(Password, hash))
+
+		needsRehash, err := newPepper.NeedsRehash(hash)
+		assert.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("GIVEN_pepper_key_id_not_in_keyring_WHEN_signing_THEN_return_err", func(t *testing.T) {
+		_, err := NewScrypt(DefaultParams, &textcoder.Utf8Coder{}, &textcoder.HexCoder{}, WithPepper("2025", pepperKeyring)).Sign(Password)
+
+		assert.ErrorIs(t, err, hmac.ErrUnknownPepper)
+	})
+
+	t.Run("GIVEN_invalid_pepper_key_id_WHEN_signing_THEN_return_err", func(t *testing.T) {
+		keyring := map[string][]byte{"a,b": []byte("pepper")}
+
+		_, err := NewScrypt(DefaultParams, &textcoder.Utf8Coder{}, &textcoder.HexCoder{}, WithPepper("a,b", keyring)).Sign(Password)
+
+		assert.ErrorIs(t, err, hmac.ErrInvalidPepperID)
+	})
+}
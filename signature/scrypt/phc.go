@@ -0,0 +1,122 @@
+package scrypt
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/imylam/crypto-utils/hmac"
+)
+
+// phcEncoding is the unpadded standard base64 encoding required by the
+// PHC string format, rejecting non-canonical trailing bits.
+var phcEncoding = base64.RawStdEncoding.Strict()
+
+// encodePhcHash encodes dk in the PHC string format
+// "$scrypt$ln=<log2(N)>,r=<r>,p=<p>[,pepper=<id>]$<salt>$<dk>".
+func encodePhcHash(params Params, pepperID string, salt, dk []byte) (string, error) {
+	if params.N <= 1 || params.N&(params.N-1) != 0 {
+		return "", errors.New(errInvalidParams)
+	}
+
+	phcParams := fmt.Sprintf("ln=%d,r=%d,p=%d", bits.TrailingZeros(uint(params.N)), params.R, params.P)
+	if pepperID != "" {
+		phcParams += ",pepper=" + pepperID
+	}
+
+	return fmt.Sprintf(
+		"$%s$%s$%s$%s",
+		ALGO,
+		phcParams,
+		phcEncoding.EncodeToString(salt),
+		phcEncoding.EncodeToString(dk),
+	), nil
+}
+
+// decodePhcHash decodes a hash in the PHC string format, validating every
+// field and the params by Params.Check. The cost is checked by the caller.
+func decodePhcHash(hash string) (Params, []byte, []byte, string, error) {
+	vals := strings.Split(hash, "$")
+
+	// "", scrypt, params, salt, scrypt derived key
+	if len(vals) != 5 || vals[0] != "" || vals[1] != ALGO {
+		return Params{}, nil, nil, "", errors.New(ERR_MALFOMATTED_HASH)
+	}
+
+	var pepperID string
+	paramVals := strings.Split(vals[2], ",")
+	if len(paramVals) == 4 {
+		pepperID = strings.TrimPrefix(paramVals[3], "pepper=")
+		if pepperID == paramVals[3] || pepperID == "" || hmac.CheckPepperID(pepperID) != nil {
+			return Params{}, nil, nil, "", errors.New(ERR_MALFOMATTED_HASH)
+		}
+		paramVals = paramVals[:3]
+	}
+	if len(paramVals) != 3 {
+		return Params{}, nil, nil, "", errors.New(ERR_MALFOMATTED_HASH)
+	}
+
+	var params Params
+	var err error
+
+	logN, err := parsePhcParam(paramVals[0], "ln")
+	if err != nil {
+		return params, nil, nil, "", fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
+	}
+	// Bounded by the bits of int32 only, checkCost bounds the memory.
+	if logN < 1 || logN > 30 {
+		return params, nil, nil, "", errors.New(errInvalidParams)
+	}
+	params.N = 1 << logN
+
+	params.R, err = parsePhcParam(paramVals[1], "r")
+	if err != nil {
+		return params, nil, nil, "", fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
+	}
+
+	params.P, err = parsePhcParam(paramVals[2], "p")
+	if err != nil {
+		return params, nil, nil, "", fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
+	}
+
+	salt, err := phcEncoding.DecodeString(vals[3])
+	if err != nil {
+		return params, nil, nil, "", fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
+	}
+	params.SaltLen = len(salt)
+
+	dk, err := phcEncoding.DecodeString(vals[4])
+	if err != nil {
+		return params, nil, nil, "", fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
+	}
+	params.DKLen = len(dk)
+
+	if err := params.Check(); err != nil {
+		return params, nil, nil, "", err
+	}
+
+	return params, salt, dk, pepperID, nil
+}
+
+// parsePhcParam parses a "<name>=<decimal>" parameter, rejecting signs
+// and leading zeros as required by the PHC string format.
+func parsePhcParam(param, name string) (int, error) {
+	value := strings.TrimPrefix(param, name+"=")
+	if value == param || value == "" {
+		return 0, fmt.Errorf("expected %s=<decimal>, got %q", name, param)
+	}
+
+	if value[0] < '0' || value[0] > '9' || (len(value) > 1 && value[0] == '0') {
+		return 0, fmt.Errorf("invalid %s value %q", name, value)
+	}
+
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value %q", name, value)
+	}
+
+	return int(n), nil
+}
@@ -1,6 +1,7 @@
 package scrypt
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/subtle"
 	"errors"
@@ -8,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/imylam/crypto-utils/hmac"
 	"github.com/imylam/crypto-utils/signature"
 	textcoder "github.com/imylam/text-coder"
 	"golang.org/x/crypto/scrypt"
@@ -21,25 +23,78 @@ const (
 
 var _ signature.Signer = (*Scrypt)(nil)
 var _ signature.Verifier = (*Scrypt)(nil)
+var _ signature.PasswordHasher = (*Scrypt)(nil)
+var _ signature.ContextSigner = (*Scrypt)(nil)
+var _ signature.ContextVerifier = (*Scrypt)(nil)
 
 type Scrypt struct {
 	// key      []byte
-	params   Params
-	pwCoder  textcoder.Coder
-	sigCoder textcoder.Coder
+	params    Params
+	pwCoder   textcoder.Coder
+	sigCoder  textcoder.Coder
+	phcFormat bool
+	pepperID  string
+	peppers   map[string][]byte
+	maxMemory int
+	maxR      int
+	maxP      int
 }
 
+// NewScrypt creates password hasher which hash passwords with the params
+// given into "N$r$p$salt$dk", with salt and dk encoded by sigCoder, or
+// into the PHC string format with WithPhcFormat.
+//
+// Verify accepts hashes in both formats, with cost up to MaxMemory, MaxR
+// and MaxP, or WithMaxCost, raised to params so that every hash signed
+// can be verified.
 func NewScrypt(
 	// key []byte,
 	params Params,
 	pwCoder textcoder.Coder,
 	sigCoder textcoder.Coder,
+	options ...func(*Scrypt),
 ) *Scrypt {
-	return &Scrypt{
+	s := &Scrypt{
 		// key:      key,
-		params:   params,
-		pwCoder:  pwCoder,
-		sigCoder: sigCoder,
+		params:    params,
+		pwCoder:   pwCoder,
+		sigCoder:  sigCoder,
+		maxMemory: MaxMemory,
+		maxR:      MaxR,
+		maxP:      MaxP,
+	}
+	for _, o := range options {
+		o(s)
+	}
+	if m := params.memory(); m > int64(s.maxMemory) && m <= maxInt {
+		s.maxMemory = int(m)
+	}
+	if params.R > s.maxR {
+		s.maxR = params.R
+	}
+	if params.P > s.maxP {
+		s.maxP = params.P
+	}
+	return s
+}
+
+// WithMaxCost replaces MaxMemory, MaxR and MaxP as the upper bounds on
+// params read from hashes by Verify and NeedsRehash, with maxMemory in
+// bytes (128 * N * r). The bounds are still raised to the hasher's params.
+func WithMaxCost(maxMemory, maxR, maxP int) func(*Scrypt) {
+	return func(s *Scrypt) {
+		s.maxMemory = maxMemory
+		s.maxR = maxR
+		s.maxP = maxP
+	}
+}
+
+// WithPhcFormat signs passwords into the PHC string format
+// "$scrypt$ln=<log2(N)>,r=<r>,p=<p>$<salt>$<dk>", with salt and dk in
+// unpadded standard base64 regardless of sigCoder.
+func WithPhcFormat() func(*Scrypt) {
+	return func(s *Scrypt) {
+		s.phcFormat = true
 	}
 }
 
@@ -58,6 +113,15 @@ func (s *Scrypt) Sign(
 		return
 	}
 
+	if err = hmac.CheckPepperID(s.pepperID); err != nil {
+		return
+	}
+
+	pwBytes, err = hmac.Pepper(pwBytes, s.pepperID, s.peppers)
+	if err != nil {
+		return
+	}
+
 	salt, err := generateRandomBytes(s.params.SaltLen)
 	if err != nil {
 		err = fmt.Errorf("failed to generate salt: %w", err)
@@ -76,6 +140,11 @@ func (s *Scrypt) Sign(
 		return "", err
 	}
 
+	if s.phcFormat {
+		pwHash, err = encodePhcHash(s.params, s.pepperID, salt, dk)
+		return
+	}
+
 	pwHash = fmt.Sprintf(
 		"%d$%d$%d$%s$%s",
 		s.params.N,
@@ -90,7 +159,7 @@ func (s *Scrypt) Sign(
 
 // Verify implements signature.Verifier.
 func (s *Scrypt) Verify(pw string, hash string) (err error) {
-	params, salt, dk, err := s.decodeHash(hash)
+	params, salt, dk, pepperID, err := s.decodeHash(hash)
 	if err != nil {
 		err = fmt.Errorf("failed to decode hash: %w", err)
 		return
@@ -102,6 +171,11 @@ func (s *Scrypt) Verify(pw string, hash string) (err error) {
 		return
 	}
 
+	pwBytes, err = hmac.Pepper(pwBytes, pepperID, s.peppers)
+	if err != nil {
+		return
+	}
+
 	other, err := scrypt.Key(pwBytes, salt, params.N, params.R, params.P, params.DKLen)
 	if err != nil {
 		err = fmt.Errorf("failed to hash password: %w", err)
@@ -109,7 +183,7 @@ func (s *Scrypt) Verify(pw string, hash string) (err error) {
 	}
 
 	if subtle.ConstantTimeCompare(dk, other) != 1 {
-		err = errors.New("hashed password does not match the hash of password provided")
+		err = signature.ErrMismatchedPassword
 		return
 	}
 
@@ -117,12 +191,38 @@ func (s *Scrypt) Verify(pw string, hash string) (err error) {
 
 }
 
-func (s *Scrypt) decodeHash(hash string) (Params, []byte, []byte, error) {
+// NeedsRehash reports whether hash was produced with params, pepper or
+// format other than those of the hasher.
+func (s *Scrypt) NeedsRehash(hash string) (bool, error) {
+	params, _, _, pepperID, err := s.decodeHash(hash)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode hash: %w", err)
+	}
+
+	phcFormat := strings.HasPrefix(hash, "$"+ALGO+"$")
+
+	return params != s.params || pepperID != s.pepperID || phcFormat != s.phcFormat, nil
+}
+
+func (s *Scrypt) decodeHash(hash string) (Params, []byte, []byte, string, error) {
+	if strings.HasPrefix(hash, "$"+ALGO+"$") {
+		params, salt, dk, pepperID, err := decodePhcHash(hash)
+		if err != nil {
+			return params, nil, nil, "", err
+		}
+
+		if err := params.checkCost(s.maxMemory, s.maxR, s.maxP); err != nil {
+			return params, nil, nil, "", err
+		}
+
+		return params, salt, dk, pepperID, nil
+	}
+
 	vals := strings.Split(hash, "$")
 
 	// P, N, R, salt, scrypt derived key
 	if len(vals) != 5 {
-		return Params{}, nil, nil, errors.New(ERR_MALFOMATTED_HASH)
+		return Params{}, nil, nil, "", errors.New(ERR_MALFOMATTED_HASH)
 	}
 
 	var params Params
@@ -130,36 +230,40 @@ func (s *Scrypt) decodeHash(hash string) (Params, []byte, []byte, error) {
 
 	params.N, err = strconv.Atoi(vals[0])
 	if err != nil {
-		return params, nil, nil, fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
+		return params, nil, nil, "", fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
 	}
 
 	params.R, err = strconv.Atoi(vals[1])
 	if err != nil {
-		return params, nil, nil, fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
+		return params, nil, nil, "", fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
 	}
 
 	params.P, err = strconv.Atoi(vals[2])
 	if err != nil {
-		return params, nil, nil, fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
+		return params, nil, nil, "", fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
 	}
 
 	salt, err := s.sigCoder.Decode(vals[3])
 	if err != nil {
-		return params, nil, nil, fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
+		return params, nil, nil, "", fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
 	}
 	params.SaltLen = len(salt)
 
 	dk, err := s.sigCoder.Decode(vals[4])
 	if err != nil {
-		return params, nil, nil, fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
+		return params, nil, nil, "", fmt.Errorf(ERR_MALFOMATTED_HASH+" %w", err)
 	}
 	params.DKLen = len(dk)
 
 	if err := params.Check(); err != nil {
-		return params, nil, nil, err
+		return params, nil, nil, "", err
+	}
+
+	if err := params.checkCost(s.maxMemory, s.maxR, s.maxP); err != nil {
+		return params, nil, nil, "", err
 	}
 
-	return params, salt, dk, nil
+	return params, salt, dk, "", nil
 }
 
 func generateRandomBytes(n int) ([]byte, error) {
@@ -172,3 +276,14 @@ func generateRandomBytes(n int) ([]byte, error) {
 
 	return b, nil
 }
+
+// SignContext signs password unless ctx is done, see signature.SignContext.
+func (s *Scrypt) SignContext(ctx context.Context, pw string) (string, error) {
+	return signature.SignContext(ctx, s, pw)
+}
+
+// VerifyContext verifies password against hash unless ctx is done, see
+// signature.VerifyContext.
+func (s *Scrypt) VerifyContext(ctx context.Context, pw string, hash string) error {
+	return signature.VerifyContext(ctx, s, pw, hash)
+}
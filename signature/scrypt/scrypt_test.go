@@ -1,8 +1,10 @@
 package scrypt
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/imylam/crypto-utils/signature"
 	textcoder "github.com/imylam/text-coder"
 	"github.com/stretchr/testify/assert"
 )
@@ -95,3 +97,242 @@ func TestVerifyOwnPasswordHash(t *testing.T) {
 		)
 	})
 }
+
+func TestNeedsRehash(t *testing.T) {
+	weakerParams := DefaultParams
+	weakerParams.N = 16384
+	weakerScrypt := NewScrypt(weakerParams, &textcoder.Utf8Coder{}, &textcoder.HexCoder{})
+
+	t.Run("GIVEN_hash_of_same_params_WHEN_checking_THEN_return_false", func(t *testing.T) {
+		needsRehash, err := scryptPw.NeedsRehash(Signature)
+
+		assert.NoError(t, err)
+		assert.False(t, needsRehash)
+	})
+
+	t.Run("GIVEN_hash_of_weaker_params_WHEN_checking_THEN_return_true", func(t *testing.T) {
+		hash, err := weakerScrypt.Sign(Password)
+		assert.NoError(t, err)
+
+		needsRehash, err := scryptPw.NeedsRehash(hash)
+
+		assert.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("GIVEN_malformatted_hash_WHEN_checking_THEN_return_err", func(t *testing.T) {
+		_, err := scryptPw.NeedsRehash("hello")
+
+		assert.ErrorContains(t, err, "failed to decode hash:")
+	})
+}
+
+func TestVerifyAndUpgrade(t *testing.T) {
+	weakerParams := DefaultParams
+	weakerParams.N = 16384
+	weakerScrypt := NewScrypt(weakerParams, &textcoder.Utf8Coder{}, &textcoder.HexCoder{})
+	weakerHash, _ := weakerScrypt.Sign(Password)
+
+	t.Run("GIVEN_up_to_date_hash_WHEN_verifying_THEN_return_no_new_hash", func(t *testing.T) {
+		hash, err := scryptPw.Sign(Password)
+		assert.NoError(t, err)
+
+		newHash, err := signature.VerifyAndUpgrade(scryptPw, Password, hash)
+
+		assert.NoError(t, err)
+		assert.Empty(t, newHash)
+	})
+
+	t.Run("GIVEN_outdated_hash_WHEN_verifying_THEN_return_new_hash", func(t *testing.T) {
+		newHash, err := signature.VerifyAndUpgrade(scryptPw, Password, weakerHash)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(newHash, "32768$8$1$"))
+
+		assert.NoError(t, scryptPw.Verify(Password, newHash))
+	})
+
+	t.Run("GIVEN_outdated_hash_and_wrong_password_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		newHash, err := signature.VerifyAndUpgrade(scryptPw, "wrong password", weakerHash)
+
+		assert.Empty(t, newHash)
+		assert.Error(t, err)
+	})
+}
+
+func TestPhcFormat(t *testing.T) {
+	// Hash of "password" produced by Python's hashlib.scrypt.
+	phcHash := "$scrypt$ln=14,r=8,p=1$c29tZXNhbHRzb21lc2FsdA$Yen7FjbO3BI6wXqNuTrPUFeKgwwVOT/w2/LyUQUT3Do"
+	phcScrypt := NewScrypt(DefaultParams, &textcoder.Utf8Coder{}, &textcoder.HexCoder{}, WithPhcFormat())
+
+	t.Run("GIVEN_phc_hash_of_another_implementation_WHEN_verifying_THEN_no_error", func(t *testing.T) {
+		err := scryptPw.Verify(Password, phcHash)
+		assert.NoError(t, err)
+	})
+
+	t.Run("GIVEN_phc_format_WHEN_signing_THEN_return_phc_hash", func(t *testing.T) {
+		hash, err := phcScrypt.Sign(Password)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hash, "$scrypt$ln=15,r=8,p=1$"))
+
+		assert.NoError(t, phcScrypt.Verify(Password, hash))
+		assert.NoError(t, scryptPw.Verify(Password, hash))
+	})
+
+	t.Run("GIVEN_legacy_hash_WHEN_verifying_with_phc_format_THEN_no_error", func(t *testing.T) {
+		hash, err := scryptPw.Sign(Password)
+		assert.NoError(t, err)
+
+		assert.NoError(t, phcScrypt.Verify(Password, hash))
+	})
+
+	t.Run("GIVEN_phc_hash_of_weaker_params_WHEN_checking_THEN_return_true", func(t *testing.T) {
+		needsRehash, err := phcScrypt.NeedsRehash(phcHash)
+
+		assert.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("GIVEN_legacy_hash_of_same_params_WHEN_checking_with_phc_format_THEN_return_true", func(t *testing.T) {
+		needsRehash, err := phcScrypt.NeedsRehash(Signature)
+
+		assert.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("GIVEN_phc_hash_of_same_params_WHEN_checking_with_legacy_format_THEN_return_true", func(t *testing.T) {
+		hash, err := phcScrypt.Sign(Password)
+		assert.NoError(t, err)
+
+		needsRehash, err := scryptPw.NeedsRehash(hash)
+
+		assert.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("GIVEN_legacy_hash_WHEN_verifying_and_upgrading_with_phc_format_THEN_return_phc_hash", func(t *testing.T) {
+		hash, err := scryptPw.Sign(Password)
+		assert.NoError(t, err)
+
+		newHash, err := signature.VerifyAndUpgrade(phcScrypt, Password, hash)
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(newHash, "$scrypt$ln=15,r=8,p=1$"))
+	})
+}
+
+func TestCostlyHashShouldThrowError(t *testing.T) {
+	salt := "c29tZXNhbHRzb21lc2FsdA"
+	dk := "Yen7FjbO3BI6wXqNuTrPUFeKgwwVOT/w2/LyUQUT3Do"
+	hexSalt := "e6ade915861f38af"
+	hexDk := "d8f83302984581f11ce4900473814d01d99a963af56038d96bf5260c05fef83d"
+
+	testCases := []struct {
+		name string
+		hash string
+	}{
+		{
+			name: "GIVEN_phc_hash_with_huge_p_WHEN_verifying_THEN_return_err",
+			hash: "$scrypt$ln=19,r=16,p=524288$" + salt + "$" + dk,
+		},
+		{
+			name: "GIVEN_phc_hash_with_huge_memory_WHEN_verifying_THEN_return_err",
+			hash: "$scrypt$ln=20,r=8,p=1$" + salt + "$" + dk,
+		},
+		{
+			name: "GIVEN_phc_hash_with_huge_r_WHEN_verifying_THEN_return_err",
+			hash: "$scrypt$ln=10,r=64,p=1$" + salt + "$" + dk,
+		},
+		{
+			name: "GIVEN_legacy_hash_with_huge_p_WHEN_verifying_THEN_return_err",
+			hash: "1024$8$1024$" + hexSalt + "$" + hexDk,
+		},
+		{
+			name: "GIVEN_legacy_hash_with_huge_memory_WHEN_verifying_THEN_return_err",
+			hash: "1048576$8$1$" + hexSalt + "$" + hexDk,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := scryptPw.Verify(Password, tc.hash)
+
+			assert.ErrorContains(t, err, "failed to decode hash: parameters out of range")
+		})
+	}
+
+	t.Run("GIVEN_max_cost_under_hash_cost_WHEN_verifying_THEN_return_err", func(t *testing.T) {
+		params := DefaultParams
+		params.N = 1024
+		s := NewScrypt(params, &textcoder.Utf8Coder{}, &textcoder.HexCoder{}, WithMaxCost(1<<20, 8, 1))
+
+		err := s.Verify(Password, "$scrypt$ln=11,r=8,p=1$"+salt+"$"+dk)
+
+		assert.ErrorContains(t, err, "failed to decode hash: parameters out of range")
+	})
+}
+
+func TestCostlyParamsShouldBeAccepted(t *testing.T) {
+	salt := "c29tZXNhbHRzb21lc2FsdA"
+	dk := "Yen7FjbO3BI6wXqNuTrPUFeKgwwVOT/w2/LyUQUT3Do"
+	errMismatch := "hashed password does not match the hash of password provided"
+
+	t.Run("GIVEN_params_over_default_max_cost_WHEN_signing_and_verifying_THEN_hash_verified", func(t *testing.T) {
+		params := DefaultParams
+		params.N = 1024
+		params.P = MaxP + 1
+		s := NewScrypt(params, &textcoder.Utf8Coder{}, &textcoder.HexCoder{})
+
+		hash, err := s.Sign(Password)
+		assert.NoError(t, err)
+
+		err = s.Verify(Password, hash)
+		assert.NoError(t, err)
+	})
+
+	t.Run("GIVEN_max_cost_over_hash_cost_WHEN_verifying_THEN_hash_decoded", func(t *testing.T) {
+		s := NewScrypt(DefaultParams, &textcoder.Utf8Coder{}, &textcoder.HexCoder{}, WithMaxCost(MaxMemory, MaxR, 2*MaxP))
+
+		err := s.Verify(Password, "$scrypt$ln=10,r=8,p=17$"+salt+"$"+dk)
+
+		assert.ErrorContainsf(t, err, errMismatch, "expected error containing %q, got %s", errMismatch, err)
+	})
+}
+
+func TestMalformattedPhcHashShouldThrowError(t *testing.T) {
+	salt := "c29tZXNhbHRzb21lc2FsdA"
+	dk := "Yen7FjbO3BI6wXqNuTrPUFeKgwwVOT/w2/LyUQUT3Do"
+
+	testCases := []struct {
+		name string
+		hash string
+	}{
+		{
+			name: "GIVEN_missing_field_WHEN_verifying_THEN_return_err",
+			hash: "$scrypt$ln=14,r=8,p=1$" + salt,
+		},
+		{
+			name: "GIVEN_params_out_of_order_WHEN_verifying_THEN_return_err",
+			hash: "$scrypt$r=8,ln=14,p=1$" + salt + "$" + dk,
+		},
+		{
+			name: "GIVEN_param_with_leading_zero_WHEN_verifying_THEN_return_err",
+			hash: "$scrypt$ln=014,r=8,p=1$" + salt + "$" + dk,
+		},
+		{
+			name: "GIVEN_ln_over_max_WHEN_verifying_THEN_return_err",
+			hash: "$scrypt$ln=31,r=8,p=1$" + salt + "$" + dk,
+		},
+		{
+			name: "GIVEN_padded_salt_WHEN_verifying_THEN_return_err",
+			hash: "$scrypt$ln=14,r=8,p=1$" + salt + "==$" + dk,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := scryptPw.Verify(Password, tc.hash)
+
+			assert.ErrorContains(t, err, "failed to decode hash:")
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package stringsdk
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+type Capitalization int
+
+const (
+	CapitalizeNone Capitalization = iota
+	// CapitalizeWords upper cases the first letter of each word.
+	CapitalizeWords
+	// CapitalizeAll upper cases every letter.
+	CapitalizeAll
+
+	DefaultPassphraseWords = 6
+)
+
+var (
+	ErrInvalidWordCount = errors.New("word count must be positive")
+)
+
+// effLargeWordlist is the EFF large wordlist of 7776 words, one per roll
+// of five dice, published under CC BY 3.0 US at
+// https://www.eff.org/files/2016/07/18/eff_large_wordlist.txt
+//
+//go:embed eff_large_wordlist.txt
+var effLargeWordlist string
+
+var passphraseWords = func() []string {
+	lines := strings.Split(strings.TrimSpace(effLargeWordlist), "\n")
+	words := make([]string, len(lines))
+	for i, line := range lines {
+		// "<dice roll>\t<word>"
+		words[i] = line[strings.IndexByte(line, '\t')+1:]
+	}
+	return words
+}()
+
+type PassphraseGenerator struct {
+	words          int
+	separator      string
+	capitalization Capitalization
+}
+
+// NewPassphraseGenerator creates generator of Diceware passphrases of
+// words words picked from the EFF large wordlist with crypto/rand,
+// separated by a space unless WithSeparator is given.
+func NewPassphraseGenerator(
+	words int,
+	options ...func(*PassphraseGenerator),
+) *PassphraseGenerator {
+	g := &PassphraseGenerator{
+		words:     words,
+		separator: " ",
+	}
+	for _, o := range options {
+		o(g)
+	}
+	return g
+}
+
+// WithSeparator joins words with separator.
+func WithSeparator(separator string) func(*PassphraseGenerator) {
+	return func(g *PassphraseGenerator) {
+		g.separator = separator
+	}
+}
+
+// WithCapitalization capitalizes words as given.
+func WithCapitalization(capitalization Capitalization) func(*PassphraseGenerator) {
+	return func(g *PassphraseGenerator) {
+		g.capitalization = capitalization
+	}
+}
+
+// Generate returns a new passphrase.
+func (g *PassphraseGenerator) Generate() (string, error) {
+	if g.words <= 0 {
+		return "", ErrInvalidWordCount
+	}
+
+	max := big.NewInt(int64(len(passphraseWords)))
+	words := make([]string, g.words)
+	for i := range words {
+		idx, err := rand.Int(randReader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to read random bytes: %w", err)
+		}
+
+		words[i] = g.capitalize(passphraseWords[idx.Int64()])
+	}
+
+	return strings.Join(words, g.separator), nil
+}
+
+// EntropyBits returns the entropy bits of the passphrases generated,
+// log2(7776) ≈ 12.9 bits per word. Separator and capitalization are fixed
+// and add none.
+func (g *PassphraseGenerator) EntropyBits() float64 {
+	if g.words <= 0 {
+		return 0
+	}
+
+	return float64(g.words) * math.Log2(float64(len(passphraseWords)))
+}
+
+func (g *PassphraseGenerator) capitalize(word string) string {
+	switch g.capitalization {
+	case CapitalizeWords:
+		return strings.ToUpper(word[:1]) + word[1:]
+	case CapitalizeAll:
+		return strings.ToUpper(word)
+	default:
+		return word
+	}
+}
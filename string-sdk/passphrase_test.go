@@ -0,0 +1,84 @@
+package stringsdk
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPassphraseWords(t *testing.T) {
+	assert.Len(t, passphraseWords, 7776)
+	assert.Equal(t, "abacus", passphraseWords[0])
+	assert.Equal(t, "zoom", passphraseWords[len(passphraseWords)-1])
+}
+
+func TestPassphraseGenerator(t *testing.T) {
+	t.Run("GIVEN_defaults_WHEN_generating_THEN_return_space_separated_wordlist_words", func(t *testing.T) {
+		g := NewPassphraseGenerator(DefaultPassphraseWords)
+
+		passphrase, err := g.Generate()
+
+		assert.NoError(t, err)
+		words := strings.Split(passphrase, " ")
+		assert.Len(t, words, DefaultPassphraseWords)
+		for _, word := range words {
+			assert.Contains(t, passphraseWords, word)
+		}
+		assert.InDelta(t, 77.5, g.EntropyBits(), 0.1)
+	})
+
+	t.Run("GIVEN_two_calls_WHEN_generating_THEN_return_different_passphrases", func(t *testing.T) {
+		g := NewPassphraseGenerator(DefaultPassphraseWords)
+
+		a, _ := g.Generate()
+		b, _ := g.Generate()
+
+		assert.NotEqual(t, a, b)
+	})
+
+	t.Run("GIVEN_random_bytes_WHEN_generating_THEN_pick_words_by_index", func(t *testing.T) {
+		defer func(r io.Reader) { randReader = r }(randReader)
+		// 7775 fits 13 bits, so rand.Int reads 2 bytes per word
+		randReader = bytes.NewReader([]byte{0, 0, 0, 1, 0x1e, 0x5f})
+
+		passphrase, err := NewPassphraseGenerator(3, WithSeparator("-")).Generate()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "abacus-abdomen-zoom", passphrase)
+	})
+
+	tests := []struct {
+		name           string
+		capitalization Capitalization
+		expected       string
+	}{
+		{"GIVEN_no_capitalization_WHEN_generating_THEN_return_lower_case", CapitalizeNone, "abacus.abdomen"},
+		{"GIVEN_capitalize_words_WHEN_generating_THEN_capitalize_first_letters", CapitalizeWords, "Abacus.Abdomen"},
+		{"GIVEN_capitalize_all_WHEN_generating_THEN_return_upper_case", CapitalizeAll, "ABACUS.ABDOMEN"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(r io.Reader) { randReader = r }(randReader)
+			randReader = bytes.NewReader([]byte{0, 0, 0, 1})
+
+			g := NewPassphraseGenerator(2, WithSeparator("."), WithCapitalization(tc.capitalization))
+			passphrase, err := g.Generate()
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, passphrase)
+		})
+	}
+
+	t.Run("GIVEN_no_words_WHEN_generating_THEN_return_err", func(t *testing.T) {
+		g := NewPassphraseGenerator(0)
+
+		_, err := g.Generate()
+
+		assert.ErrorIs(t, err, ErrInvalidWordCount)
+		assert.Equal(t, float64(0), g.EntropyBits())
+	})
+}
@@ -0,0 +1,166 @@
+package stringsdk
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+const (
+	ALPHABET_LOWER   = "abcdefghijklmnopqrstuvwxyz"
+	ALPHABET_UPPER   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	ALPHABET_DIGITS  = "0123456789"
+	ALPHABET_SYMBOLS = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+	// AMBIGUOUS_CHARS are easily confused when read or typed by hand.
+	AMBIGUOUS_CHARS = "Il1|O0o`'\""
+)
+
+var (
+	ErrInvalidPolicy = errors.New("invalid password policy")
+)
+
+// PasswordPolicy describes passwords to generate with GeneratePassword.
+type PasswordPolicy struct {
+	Length     int
+	MinLower   int
+	MinUpper   int
+	MinDigits  int
+	MinSymbols int
+	// Symbols are the ASCII symbols to use, none if empty.
+	Symbols string
+	// ExcludeAmbiguous leaves out AMBIGUOUS_CHARS.
+	ExcludeAmbiguous bool
+}
+
+// DefaultPasswordPolicy generates 16 characters passwords with at least one
+// of each character class, without ambiguous characters.
+var DefaultPasswordPolicy = PasswordPolicy{
+	Length:           16,
+	MinLower:         1,
+	MinUpper:         1,
+	MinDigits:        1,
+	MinSymbols:       1,
+	Symbols:          ALPHABET_SYMBOLS,
+	ExcludeAmbiguous: true,
+}
+
+// Check returns ErrInvalidPolicy if no password can satisfy the policy.
+func (p PasswordPolicy) Check() error {
+	if p.Length <= 0 {
+		return fmt.Errorf("%w: length must be positive", ErrInvalidPolicy)
+	}
+
+	if p.MinLower < 0 || p.MinUpper < 0 || p.MinDigits < 0 || p.MinSymbols < 0 {
+		return fmt.Errorf("%w: minimum counts must not be negative", ErrInvalidPolicy)
+	}
+
+	if p.MinLower+p.MinUpper+p.MinDigits+p.MinSymbols > p.Length {
+		return fmt.Errorf("%w: minimum counts exceed length %d", ErrInvalidPolicy, p.Length)
+	}
+
+	for _, r := range p.Symbols {
+		if r > unicode.MaxASCII {
+			return fmt.Errorf("%w: symbols must be ASCII, got %q", ErrInvalidPolicy, r)
+		}
+	}
+
+	if p.MinSymbols > 0 && p.symbols() == "" {
+		return fmt.Errorf("%w: minimum symbols given without symbols", ErrInvalidPolicy)
+	}
+
+	if err := checkAlphabet(p.alphabet()); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidPolicy, err)
+	}
+
+	return nil
+}
+
+// GeneratePassword returns a password satisfying policy, drawing every
+// character and its position with crypto/rand.
+func GeneratePassword(policy PasswordPolicy) (string, error) {
+	if err := policy.Check(); err != nil {
+		return "", err
+	}
+
+	required := []struct {
+		count    int
+		alphabet string
+	}{
+		{policy.MinLower, policy.exclude(ALPHABET_LOWER)},
+		{policy.MinUpper, policy.exclude(ALPHABET_UPPER)},
+		{policy.MinDigits, policy.exclude(ALPHABET_DIGITS)},
+		{policy.MinSymbols, policy.symbols()},
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(policy.Length)
+	for _, r := range required {
+		if r.count == 0 {
+			continue
+		}
+		if len(r.alphabet) == 1 {
+			sb.WriteString(strings.Repeat(r.alphabet, r.count))
+			continue
+		}
+
+		chars, err := SecureRandomStr(r.count, r.alphabet)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(chars)
+	}
+
+	rest, err := SecureRandomStr(policy.Length-sb.Len(), policy.alphabet())
+	if err != nil {
+		return "", err
+	}
+	sb.WriteString(rest)
+
+	pw := []byte(sb.String())
+	if err := shuffle(pw); err != nil {
+		return "", err
+	}
+
+	return string(pw), nil
+}
+
+func (p PasswordPolicy) alphabet() string {
+	return p.exclude(ALPHABET_LOWER) +
+		p.exclude(ALPHABET_UPPER) +
+		p.exclude(ALPHABET_DIGITS) +
+		p.symbols()
+}
+
+func (p PasswordPolicy) symbols() string {
+	return p.exclude(p.Symbols)
+}
+
+func (p PasswordPolicy) exclude(alphabet string) string {
+	if !p.ExcludeAmbiguous {
+		return alphabet
+	}
+
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(AMBIGUOUS_CHARS, r) {
+			return -1
+		}
+		return r
+	}, alphabet)
+}
+
+// shuffle permutes b uniformly with the Fisher-Yates shuffle.
+func shuffle(b []byte) error {
+	for i := len(b) - 1; i > 0; i-- {
+		j, err := rand.Int(randReader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return fmt.Errorf("failed to read random bytes: %w", err)
+		}
+		b[i], b[j.Int64()] = b[j.Int64()], b[i]
+	}
+
+	return nil
+}
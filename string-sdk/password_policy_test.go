@@ -0,0 +1,76 @@
+package stringsdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	t.Run("GIVEN_default_policy_WHEN_generating_THEN_satisfy_policy", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			pw, err := GeneratePassword(DefaultPasswordPolicy)
+
+			assert.NoError(t, err)
+			assert.Len(t, pw, DefaultPasswordPolicy.Length)
+			assert.GreaterOrEqual(t, countOf(pw, ALPHABET_LOWER), 1)
+			assert.GreaterOrEqual(t, countOf(pw, ALPHABET_UPPER), 1)
+			assert.GreaterOrEqual(t, countOf(pw, ALPHABET_DIGITS), 1)
+			assert.GreaterOrEqual(t, countOf(pw, ALPHABET_SYMBOLS), 1)
+			assert.Equal(t, 0, countOf(pw, AMBIGUOUS_CHARS))
+		}
+	})
+
+	t.Run("GIVEN_minimum_counts_filling_length_WHEN_generating_THEN_return_exact_counts", func(t *testing.T) {
+		policy := PasswordPolicy{Length: 10, MinLower: 2, MinUpper: 3, MinDigits: 4, MinSymbols: 1, Symbols: "#"}
+
+		pw, err := GeneratePassword(policy)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, countOf(pw, ALPHABET_LOWER))
+		assert.Equal(t, 3, countOf(pw, ALPHABET_UPPER))
+		assert.Equal(t, 4, countOf(pw, ALPHABET_DIGITS))
+		assert.Equal(t, 1, strings.Count(pw, "#"))
+	})
+
+	t.Run("GIVEN_no_symbols_WHEN_generating_THEN_return_alphanumeric", func(t *testing.T) {
+		pw, err := GeneratePassword(PasswordPolicy{Length: 64})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 64, countOf(pw, ALPHABET_ALPHANUMERIC))
+	})
+
+	tests := []struct {
+		name   string
+		policy PasswordPolicy
+		errMsg string
+	}{
+		{"GIVEN_zero_length_WHEN_generating_THEN_return_err", PasswordPolicy{}, "length must be positive"},
+		{"GIVEN_negative_count_WHEN_generating_THEN_return_err", PasswordPolicy{Length: 8, MinDigits: -1}, "must not be negative"},
+		{"GIVEN_counts_over_length_WHEN_generating_THEN_return_err", PasswordPolicy{Length: 2, MinLower: 2, MinDigits: 1}, "exceed length 2"},
+		{"GIVEN_min_symbols_without_symbols_WHEN_generating_THEN_return_err", PasswordPolicy{Length: 8, MinSymbols: 1}, "without symbols"},
+		{"GIVEN_only_ambiguous_symbols_WHEN_generating_THEN_return_err", PasswordPolicy{Length: 8, MinSymbols: 1, Symbols: "|", ExcludeAmbiguous: true}, "without symbols"},
+		{"GIVEN_non_ascii_symbols_WHEN_generating_THEN_return_err", PasswordPolicy{Length: 12, MinSymbols: 2, Symbols: "€£"}, "symbols must be ASCII"},
+		{"GIVEN_symbols_overlapping_letters_WHEN_generating_THEN_return_err", PasswordPolicy{Length: 8, Symbols: "a!"}, "distinct bytes"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := GeneratePassword(tc.policy)
+
+			assert.ErrorIs(t, err, ErrInvalidPolicy)
+			assert.ErrorContainsf(t, err, tc.errMsg, "expected error containing %q, got %s", tc.errMsg, err)
+		})
+	}
+}
+
+func countOf(s string, chars string) int {
+	count := 0
+	for _, r := range s {
+		if strings.ContainsRune(chars, r) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,165 @@
+package stringsdk
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrCommonPassword = errors.New("password is too common")
+	ErrWeakPassword   = errors.New("password is too weak")
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsTxt string
+
+var commonPasswords = func() map[string]struct{} {
+	passwords := make(map[string]struct{})
+	for _, pw := range strings.Fields(commonPasswordsTxt) {
+		passwords[pw] = struct{}{}
+	}
+	return passwords
+}()
+
+// IsCommonPassword reports whether pw, ignoring case, is in the embedded
+// list of commonly used passwords.
+func IsCommonPassword(pw string) bool {
+	_, ok := commonPasswords[strings.ToLower(pw)]
+	return ok
+}
+
+// keyboardRows are the rows of a US QWERTY keyboard, for spotting runs of
+// adjacent keys such as "qwerty" or "asdf".
+var keyboardRows = []string{
+	"`1234567890-=",
+	"qwertyuiop[]\\",
+	"asdfghjkl;'",
+	"zxcvbnm,./",
+}
+
+// patternBits is the entropy bits of a character which repeats the one
+// before it, or continues a sequence or a keyboard run with it.
+const patternBits = 1
+
+// EstimateEntropy estimates the entropy bits of pw as if its characters
+// were drawn at random from the character classes it uses, except that
+// characters repeating the one before them, or continuing a sequence
+// ("abc", "321") or a keyboard run ("qwerty") with it, count for
+// patternBits only. Common passwords have 0 bits, and common passwords
+// followed by digits and symbols, like "Password123!", count as one of
+// the embedded list plus the suffix.
+//
+// It is still an upper bound for user-chosen passwords: words, names and
+// dates outside the embedded list count as random characters.
+func EstimateEntropy(pw string) float64 {
+	if pw == "" || IsCommonPassword(pw) {
+		return 0
+	}
+
+	runes := []rune(pw)
+	bitsPerChar := math.Log2(float64(charPoolSize(runes)))
+
+	start := 0
+	entropy := 0.0
+	base := strings.TrimRightFunc(pw, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if base != "" && IsCommonPassword(base) {
+		start = len([]rune(base))
+		entropy = math.Log2(float64(len(commonPasswords)))
+	}
+
+	for i := start; i < len(runes); i++ {
+		if i > start && isPattern(runes[i-1], runes[i]) {
+			entropy += patternBits
+		} else {
+			entropy += bitsPerChar
+		}
+	}
+
+	return entropy
+}
+
+// charPoolSize returns the number of characters in the character classes
+// used by runes.
+func charPoolSize(runes []rune) int {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range runes {
+		switch {
+		case r > unicode.MaxASCII:
+			other = true
+		case strings.ContainsRune(ALPHABET_LOWER, r):
+			lower = true
+		case strings.ContainsRune(ALPHABET_UPPER, r):
+			upper = true
+		case strings.ContainsRune(ALPHABET_DIGITS, r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	pool := 0
+	if lower {
+		pool += len(ALPHABET_LOWER)
+	}
+	if upper {
+		pool += len(ALPHABET_UPPER)
+	}
+	if digit {
+		pool += len(ALPHABET_DIGITS)
+	}
+	if symbol {
+		// printable ASCII symbols and space
+		pool += len(ALPHABET_SYMBOLS) + 1
+	}
+	if other {
+		pool += 100
+	}
+
+	return pool
+}
+
+// isPattern reports whether r, ignoring case, repeats prev, or follows it
+// in the alphabet, the digits or a keyboard row, in either direction.
+func isPattern(prev, r rune) bool {
+	prev, r = unicode.ToLower(prev), unicode.ToLower(r)
+	if prev == r {
+		return true
+	}
+
+	isAlnum := func(c rune) bool {
+		return strings.ContainsRune(ALPHABET_LOWER, c) || strings.ContainsRune(ALPHABET_DIGITS, c)
+	}
+	if isAlnum(prev) && isAlnum(r) && (r-prev == 1 || prev-r == 1) {
+		return true
+	}
+
+	for _, row := range keyboardRows {
+		i, j := strings.IndexRune(row, prev), strings.IndexRune(row, r)
+		if i >= 0 && j >= 0 && (j-i == 1 || i-j == 1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckPasswordStrength returns ErrCommonPassword if pw is a common
+// password, or ErrWeakPassword if its estimated entropy is below
+// minEntropyBits.
+func CheckPasswordStrength(pw string, minEntropyBits float64) error {
+	if IsCommonPassword(pw) {
+		return ErrCommonPassword
+	}
+
+	if entropy := EstimateEntropy(pw); entropy < minEntropyBits {
+		return fmt.Errorf("%w: %.1f bits, at least %.1f bits expected", ErrWeakPassword, entropy, minEntropyBits)
+	}
+
+	return nil
+}
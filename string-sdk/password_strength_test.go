@@ -0,0 +1,59 @@
+package stringsdk
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateEntropy(t *testing.T) {
+	tests := []struct {
+		name    string
+		pw      string
+		entropy float64
+	}{
+		{"GIVEN_empty_password_WHEN_estimating_THEN_return_0", "", 0},
+		{"GIVEN_common_password_WHEN_estimating_THEN_return_0", "Password", 0},
+		{"GIVEN_lowercase_WHEN_estimating_THEN_use_pool_of_26", "xkfmpwtz", 8 * 4.700439718141092},
+		{"GIVEN_digits_WHEN_estimating_THEN_use_pool_of_10", "40718", 5 * 3.321928094887362},
+		{"GIVEN_all_classes_WHEN_estimating_THEN_use_pool_of_95", "aX3!", 4 * 6.569855608330948},
+		{"GIVEN_repeats_WHEN_estimating_THEN_count_1_bit_per_repeat", "aaaaaaaaaaaaaaaa", 4.700439718141092 + 15},
+		{"GIVEN_sequences_WHEN_estimating_THEN_count_1_bit_per_step", "bcdefg654321", 2*5.169925001442312 + 10},
+		{"GIVEN_keyboard_run_WHEN_estimating_THEN_count_1_bit_per_key", "asdfghjk", 4.700439718141092 + 7},
+		{"GIVEN_common_password_with_suffix_WHEN_estimating_THEN_count_list_and_suffix", "Summer2024!", math.Log2(float64(len(commonPasswords))) + 5*6.569855608330948},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.entropy, EstimateEntropy(tc.pw), 1e-9)
+		})
+	}
+}
+
+func TestCheckPasswordStrength(t *testing.T) {
+	t.Run("GIVEN_common_password_WHEN_checking_THEN_return_err", func(t *testing.T) {
+		assert.True(t, IsCommonPassword("LetMeIn"))
+		assert.ErrorIs(t, CheckPasswordStrength("LetMeIn", 0), ErrCommonPassword)
+	})
+
+	t.Run("GIVEN_short_password_WHEN_checking_THEN_return_err", func(t *testing.T) {
+		err := CheckPasswordStrength("xkfmpwtz", 60)
+
+		assert.ErrorIs(t, err, ErrWeakPassword)
+		assert.ErrorContains(t, err, "37.6 bits")
+	})
+
+	for _, pw := range []string{"aaaaaaaaaaaaaaaa", "Password123!", "qwertyuiop12345", "Summer2024!"} {
+		t.Run("GIVEN_patterned_password_"+pw+"_WHEN_checking_THEN_return_err", func(t *testing.T) {
+			assert.ErrorIs(t, CheckPasswordStrength(pw, 60), ErrWeakPassword)
+		})
+	}
+
+	t.Run("GIVEN_generated_password_WHEN_checking_THEN_return_nil", func(t *testing.T) {
+		pw, err := GeneratePassword(DefaultPasswordPolicy)
+		assert.NoError(t, err)
+
+		assert.NoError(t, CheckPasswordStrength(pw, 60))
+	})
+}
@@ -13,6 +13,9 @@ const (
 	charIdxMax  = 63 / charIdxBits   // # of letter indices fitting in 63 bits
 )
 
+// RandomStr returns a pseudo-random string of length characters seeded by
+// the current time. It is predictable and only meant for tests, use
+// SecureRandomStr for tokens or passwords.
 func RandomStr(length int) string {
 	src := rand.NewSource(time.Now().UnixNano())
 
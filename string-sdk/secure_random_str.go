@@ -0,0 +1,108 @@
+package stringsdk
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"strings"
+)
+
+const (
+	ALPHABET_ALPHANUMERIC = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	ALPHABET_URL_SAFE     = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+	ALPHABET_HEX          = "0123456789abcdef"
+)
+
+var (
+	ErrInvalidAlphabet = errors.New("alphabet must have 2 to 256 distinct bytes")
+	ErrInvalidLength   = errors.New("length must not be negative")
+	ErrInvalidEntropy  = errors.New("entropy bits must be positive")
+)
+
+// randReader is the source of randomness, replaced in tests.
+var randReader io.Reader = rand.Reader
+
+// SecureRandomStr returns a string of length characters drawn uniformly
+// from alphabet using crypto/rand, suitable for tokens and passwords.
+// Characters of alphabet are single bytes, so custom alphabets must be
+// ASCII.
+//
+// Random bytes are masked to the smallest power of two covering alphabet
+// and values outside of it are rejected, so no character is favored.
+func SecureRandomStr(length int, alphabet string) (string, error) {
+	if length < 0 {
+		return "", ErrInvalidLength
+	}
+	if err := checkAlphabet(alphabet); err != nil {
+		return "", err
+	}
+
+	mask := 1<<bits.Len(uint(len(alphabet)-1)) - 1
+
+	sb := strings.Builder{}
+	sb.Grow(length)
+	buf := make([]byte, length+length/2+1)
+	for sb.Len() < length {
+		if _, err := io.ReadFull(randReader, buf); err != nil {
+			return "", fmt.Errorf("failed to read random bytes: %w", err)
+		}
+
+		for _, b := range buf {
+			if idx := int(b) & mask; idx < len(alphabet) {
+				sb.WriteByte(alphabet[idx])
+				if sb.Len() == length {
+					break
+				}
+			}
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// SecureToken returns a random string from alphabet carrying at least
+// entropyBits bits of entropy.
+func SecureToken(entropyBits int, alphabet string) (string, error) {
+	if entropyBits <= 0 {
+		return "", ErrInvalidEntropy
+	}
+	if err := checkAlphabet(alphabet); err != nil {
+		return "", err
+	}
+
+	bitsPerChar := math.Log2(float64(len(alphabet)))
+	length := int(math.Ceil(float64(entropyBits) / bitsPerChar))
+
+	return SecureRandomStr(length, alphabet)
+}
+
+// SecureTokenHex returns a lowercase hex token carrying at least
+// entropyBits bits of entropy.
+func SecureTokenHex(entropyBits int) (string, error) {
+	return SecureToken(entropyBits, ALPHABET_HEX)
+}
+
+// SecureTokenURLSafe returns a token of the unpadded base64url alphabet
+// carrying at least entropyBits bits of entropy.
+func SecureTokenURLSafe(entropyBits int) (string, error) {
+	return SecureToken(entropyBits, ALPHABET_URL_SAFE)
+}
+
+func checkAlphabet(alphabet string) error {
+	if len(alphabet) < 2 || len(alphabet) > 256 {
+		return ErrInvalidAlphabet
+	}
+
+	var seen [256]bool
+	for i := 0; i < len(alphabet); i++ {
+		if seen[alphabet[i]] {
+			return ErrInvalidAlphabet
+		}
+		seen[alphabet[i]] = true
+	}
+
+	return nil
+}
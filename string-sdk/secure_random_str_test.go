@@ -0,0 +1,114 @@
+package stringsdk
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecureRandomStr(t *testing.T) {
+	t.Run("GIVEN_alphabets_WHEN_generating_THEN_return_length_chars_of_alphabet", func(t *testing.T) {
+		for _, alphabet := range []string{ALPHABET_ALPHANUMERIC, ALPHABET_URL_SAFE, ALPHABET_HEX, "ab", "xyz"} {
+			for length := 0; length < 50; length++ {
+				str, err := SecureRandomStr(length, alphabet)
+
+				assert.NoError(t, err)
+				assert.Len(t, str, length)
+				for _, c := range str {
+					assert.Contains(t, alphabet, string(c))
+				}
+			}
+		}
+	})
+
+	t.Run("GIVEN_two_calls_WHEN_generating_THEN_return_different_strings", func(t *testing.T) {
+		a, _ := SecureRandomStr(32, ALPHABET_ALPHANUMERIC)
+		b, _ := SecureRandomStr(32, ALPHABET_ALPHANUMERIC)
+
+		assert.NotEqual(t, a, b)
+	})
+
+	t.Run("GIVEN_alphabet_not_power_of_two_WHEN_generating_THEN_distribute_chars_evenly", func(t *testing.T) {
+		str, err := SecureRandomStr(60000, "abc")
+		assert.NoError(t, err)
+
+		for _, c := range "abc" {
+			count := strings.Count(str, string(c))
+			assert.InDelta(t, 20000, count, 1000)
+		}
+	})
+
+	t.Run("GIVEN_random_bytes_outside_alphabet_WHEN_generating_THEN_reject_them", func(t *testing.T) {
+		defer func(r io.Reader) { randReader = r }(randReader)
+		// mask of "abc" is 3, so 3 and 7 are rejected
+		randReader = bytes.NewReader([]byte{3, 7, 2, 1, 4, 0, 3, 3, 3, 3})
+
+		str, err := SecureRandomStr(4, "abc")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "cbaa", str)
+	})
+
+	t.Run("GIVEN_failing_reader_WHEN_generating_THEN_return_err", func(t *testing.T) {
+		defer func(r io.Reader) { randReader = r }(randReader)
+		randReader = bytes.NewReader(nil)
+
+		_, err := SecureRandomStr(4, "abc")
+
+		assert.ErrorContains(t, err, "failed to read random bytes")
+	})
+
+	tests := []struct {
+		name     string
+		length   int
+		alphabet string
+		err      error
+	}{
+		{"GIVEN_negative_length_WHEN_generating_THEN_return_err", -1, ALPHABET_HEX, ErrInvalidLength},
+		{"GIVEN_one_char_alphabet_WHEN_generating_THEN_return_err", 4, "a", ErrInvalidAlphabet},
+		{"GIVEN_duplicate_chars_WHEN_generating_THEN_return_err", 4, "abca", ErrInvalidAlphabet},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := SecureRandomStr(tc.length, tc.alphabet)
+
+			assert.Truef(t, errors.Is(err, tc.err), "expected error %q, got %s", tc.err, err)
+		})
+	}
+}
+
+func TestSecureToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  func(int) (string, error)
+		bits   int
+		length int
+	}{
+		{"GIVEN_128_bits_WHEN_hex_token_THEN_return_32_chars", SecureTokenHex, 128, 32},
+		{"GIVEN_128_bits_WHEN_url_safe_token_THEN_return_22_chars", SecureTokenURLSafe, 128, 22},
+		{"GIVEN_256_bits_WHEN_url_safe_token_THEN_return_43_chars", SecureTokenURLSafe, 256, 43},
+		{"GIVEN_128_bits_WHEN_alphanumeric_token_THEN_return_22_chars", func(bits int) (string, error) {
+			return SecureToken(bits, ALPHABET_ALPHANUMERIC)
+		}, 128, 22},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := tc.token(tc.bits)
+
+			assert.NoError(t, err)
+			assert.Len(t, token, tc.length)
+		})
+	}
+
+	t.Run("GIVEN_zero_bits_WHEN_token_THEN_return_err", func(t *testing.T) {
+		_, err := SecureTokenHex(0)
+
+		assert.ErrorIs(t, err, ErrInvalidEntropy)
+	})
+}